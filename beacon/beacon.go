@@ -0,0 +1,108 @@
+package beacon
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"go-cryptology/ibe"
+)
+
+var (
+	ErrRoundGap     = errors.New("[BEACON] rounds are not consecutive")
+	ErrInvalidRound = errors.New("[BEACON] malformed round")
+	ErrNotFound     = errors.New("[BEACON] round not found")
+)
+
+//	one beacon round, the output of round r is chained into the message of round r+1
+type Round struct {
+	Number uint64
+	//	VRF proof of the leader or group signature of the committee
+	Proof  []byte
+	Output []byte
+}
+
+//	checks the proof of one round and that the output is derived from it
+type Verifier interface {
+	Verify(message []byte, proof []byte, output []byte) error
+}
+
+//	message signed in round number, H(previous output || number),
+//	the previous output of round 1 is the genesis seed
+func RoundMessage(number uint64, previous []byte) []byte {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], number)
+	h := sha256.New()
+	h.Write(previous)
+	h.Write(b[:])
+	return h.Sum(nil)
+}
+
+//	message signed in round number of an unchained beacon, it does not depend on the
+//	previous round and is the identity ibe.RoundIdentity(number), so the group signature
+//	of the round is the key that opens ciphertexts of ibe.EncryptToRound
+func UnchainedRoundMessage(number uint64) []byte {
+	return ibe.RoundIdentity(number)
+}
+
+//	verify round against the output of the round before it
+func VerifyRound(verifier Verifier, previous []byte, round *Round) error {
+	if round == nil || round.Number == 0 {
+		return ErrInvalidRound
+	}
+	if err := verifier.Verify(RoundMessage(round.Number, previous), round.Proof, round.Output); err != nil {
+		return fmt.Errorf("[BEACON] round %d: %w", round.Number, err)
+	}
+	return nil
+}
+
+//	verify round of an unchained beacon
+func VerifyUnchainedRound(verifier Verifier, round *Round) error {
+	if round == nil || round.Number == 0 {
+		return ErrInvalidRound
+	}
+	if err := verifier.Verify(UnchainedRoundMessage(round.Number), round.Proof, round.Output); err != nil {
+		return fmt.Errorf("[BEACON] round %d: %w", round.Number, err)
+	}
+	return nil
+}
+
+//	verify consecutive rounds, previous is the output of the round before rounds[0]
+func VerifyChain(verifier Verifier, previous []byte, rounds []*Round) error {
+	for i, round := range rounds {
+		if i > 0 && (round == nil || round.Number != rounds[i-1].Number+1) {
+			return ErrRoundGap
+		}
+		if err := VerifyRound(verifier, previous, round); err != nil {
+			return err
+		}
+		previous = round.Output
+	}
+	return nil
+}
+
+//	number || len(proof) || proof || output
+func (r *Round) Serialize() []byte {
+	b := make([]byte, 12, 12+len(r.Proof)+len(r.Output))
+	binary.BigEndian.PutUint64(b[:8], r.Number)
+	binary.BigEndian.PutUint32(b[8:12], uint32(len(r.Proof)))
+	b = append(b, r.Proof...)
+	return append(b, r.Output...)
+}
+
+//	deserialize round, the proof and the output are not verified
+func DeserializeRound(b []byte) (*Round, error) {
+	if len(b) < 12 {
+		return nil, ErrInvalidRound
+	}
+	proofSize := binary.BigEndian.Uint32(b[8:12])
+	if uint64(len(b)-12) < uint64(proofSize) {
+		return nil, ErrInvalidRound
+	}
+	return &Round{
+		Number: binary.BigEndian.Uint64(b[:8]),
+		Proof:  append([]byte(nil), b[12:12+proofSize]...),
+		Output: append([]byte(nil), b[12+proofSize:]...),
+	}, nil
+}
@@ -0,0 +1,128 @@
+package beacon
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"go-cryptology/vrf"
+)
+
+func TestVRFChain(t *testing.T) {
+	fmt.Println("Test : beacon chain of a VRF leader ...")
+
+	priKey, pubKey := vrf.GenerateKey()
+	genesis := []byte("genesis")
+	chain := NewChain(genesis, NewVRFVerifier(pubKey), NewMemoryStore())
+
+	t0 := time.Now()
+
+	var rounds []*Round
+	previous := genesis
+	for i := 0; i < 5; i++ {
+		number, _, err := chain.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		round := NewVRFRound(number, previous, priKey)
+		if err := chain.Append(round); err != nil {
+			t.Fatalf("got result %v but expected %v\n", err, nil)
+		}
+		rounds = append(rounds, round)
+		previous = round.Output
+	}
+
+	last, err := chain.Last()
+	if err != nil || last.Number != 5 {
+		t.Fatalf("got result %v but expected %v\n", last, 5)
+	}
+	if err := VerifyChain(NewVRFVerifier(pubKey), genesis, rounds); err != nil {
+		t.Fatalf("got result %v but expected %v\n", err, nil)
+	}
+	//	a suffix of the chain is verified from the output before it
+	if err := VerifyChain(NewVRFVerifier(pubKey), rounds[1].Output, rounds[2:]); err != nil {
+		t.Fatalf("got result %v but expected %v\n", err, nil)
+	}
+
+	//	rounds must be appended in order
+	if err := chain.Append(NewVRFRound(7, previous, priKey)); !errors.Is(err, ErrRoundGap) {
+		t.Fatalf("got result %v but expected %v\n", err, ErrRoundGap)
+	}
+	if err := VerifyChain(NewVRFVerifier(pubKey), genesis, append(rounds[:2:2], rounds[3:]...)); !errors.Is(err, ErrRoundGap) {
+		t.Fatalf("got result %v but expected %v\n", err, ErrRoundGap)
+	}
+
+	//	the round of another leader or on top of another output is rejected
+	otherKey, _ := vrf.GenerateKey()
+	if err := chain.Append(NewVRFRound(6, previous, otherKey)); !errors.Is(err, vrf.ErrProofMismatch) {
+		t.Fatalf("got result %v but expected %v\n", err, vrf.ErrProofMismatch)
+	}
+	if err := chain.Append(NewVRFRound(6, genesis, priKey)); err == nil {
+		t.Fatalf("got result %v but expected %v\n", err, vrf.ErrProofMismatch)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func TestChainConcurrentAppend(t *testing.T) {
+	fmt.Println("Test : beacon chain concurrent append ...")
+
+	priKey, pubKey := vrf.GenerateKey()
+	genesis := []byte("genesis")
+	chain := NewChain(genesis, NewVRFVerifier(pubKey), NewMemoryStore())
+
+	t0 := time.Now()
+
+	//	every goroutine appends the same round 1, only the first one may land
+	round := NewVRFRound(1, genesis, priKey)
+	results := make([]error, 32)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = chain.Append(round)
+		}(i)
+	}
+	wg.Wait()
+
+	appended := 0
+	for _, err := range results {
+		if err == nil {
+			appended++
+		} else if !errors.Is(err, ErrRoundGap) {
+			t.Fatalf("got result %v but expected %v\n", err, ErrRoundGap)
+		}
+	}
+	if appended != 1 {
+		t.Fatalf("got result %v but expected %v\n", appended, 1)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func TestRoundSerialize(t *testing.T) {
+	fmt.Println("Test : beacon round serialize ...")
+
+	priKey, _ := vrf.GenerateKey()
+
+	t0 := time.Now()
+
+	round := NewVRFRound(42, []byte("previous"), priKey)
+	result, err := DeserializeRound(round.Serialize())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Number != round.Number || !bytes.Equal(result.Proof, round.Proof) || !bytes.Equal(result.Output, round.Output) {
+		t.Fatalf("got round %v but expected %v\n", result, round)
+	}
+
+	if _, err := DeserializeRound(round.Serialize()[:20]); !errors.Is(err, ErrInvalidRound) {
+		t.Fatalf("got result %v but expected %v\n", err, ErrInvalidRound)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
@@ -0,0 +1,89 @@
+package beacon
+
+import (
+	"errors"
+	"sync"
+)
+
+//	chain of verified rounds starting from a genesis seed
+type Chain struct {
+	//	serializes appends, the head must not move between verify and put
+	mu       sync.Mutex
+	genesis  []byte
+	verifier Verifier
+	store    Store
+	//	rounds sign UnchainedRoundMessage instead of RoundMessage
+	unchained bool
+}
+
+//	create chain, rounds already in store are trusted
+func NewChain(genesis []byte, verifier Verifier, store Store) *Chain {
+	return &Chain{
+		genesis:  append([]byte(nil), genesis...),
+		verifier: verifier,
+		store:    store,
+	}
+}
+
+//	create chain of an unchained beacon, every round signs UnchainedRoundMessage.
+//	rounds are still appended in order, but none of them depends on the one before
+func NewUnchainedChain(verifier Verifier, store Store) *Chain {
+	return &Chain{verifier: verifier, store: store, unchained: true}
+}
+
+//	number and message of the next round
+func (c *Chain) Next() (number uint64, message []byte, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	number, previous, err := c.head()
+	if err != nil {
+		return 0, nil, err
+	}
+	if c.unchained {
+		return number + 1, UnchainedRoundMessage(number + 1), nil
+	}
+	return number + 1, RoundMessage(number+1, previous), nil
+}
+
+//	verify round against the head of the chain and persist it
+func (c *Chain) Append(round *Round) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	number, previous, err := c.head()
+	if err != nil {
+		return err
+	}
+	if round == nil || round.Number != number+1 {
+		return ErrRoundGap
+	}
+	if c.unchained {
+		err = VerifyUnchainedRound(c.verifier, round)
+	} else {
+		err = VerifyRound(c.verifier, previous, round)
+	}
+	if err != nil {
+		return err
+	}
+	return c.store.Put(round)
+}
+
+//	latest round, ErrNotFound before round 1
+func (c *Chain) Last() (*Round, error) {
+	return c.store.Last()
+}
+
+//	round by number
+func (c *Chain) Get(number uint64) (*Round, error) {
+	return c.store.Get(number)
+}
+
+func (c *Chain) head() (uint64, []byte, error) {
+	last, err := c.store.Last()
+	if errors.Is(err, ErrNotFound) {
+		return 0, c.genesis, nil
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+	return last.Number, last.Output, nil
+}
@@ -0,0 +1,77 @@
+package beacon
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+
+	"github.com/phoreproject/bls/g2pubs"
+	"go-cryptology/bls"
+)
+
+var (
+	ErrInvalidSignature = errors.New("[BEACON] invalid group signature")
+	ErrInvalidOutput    = errors.New("[BEACON] output is not derived from the proof")
+)
+
+//	size of the compressed group signature
+const SignatureSize = 48
+
+//	verifier of rounds produced by a threshold BLS committee
+type BLSVerifier struct {
+	pubKey *g2pubs.PublicKey
+}
+
+//	create verifier of the group public key
+func NewBLSVerifier(pubKey *g2pubs.PublicKey) *BLSVerifier {
+	return &BLSVerifier{pubKey: pubKey}
+}
+
+//	the proof is the group signature and the output is its SHA256 digest
+func (v *BLSVerifier) Verify(message []byte, proof []byte, output []byte) error {
+	if len(proof) != SignatureSize {
+		return ErrInvalidSignature
+	}
+	var b [SignatureSize]byte
+	copy(b[:], proof)
+	signature, err := g2pubs.DeserializeSignature(b)
+	if err != nil || !bls.Verify(message, v.pubKey, signature) {
+		return ErrInvalidSignature
+	}
+	digest := sha256.Sum256(proof)
+	if !bytes.Equal(digest[:], output) {
+		return ErrInvalidOutput
+	}
+	return nil
+}
+
+//	partial signature of a committee member for round number
+func PartialSign(number uint64, previous []byte, share *bls.KeyShare) *bls.PartialSignature {
+	return bls.PartialSign(RoundMessage(number, previous), share)
+}
+
+//	verify partial signature of round number against the polynomial commitments of the committee
+func VerifyPartial(number uint64, previous []byte, commitments []*g2pubs.PublicKey, partial *bls.PartialSignature) bool {
+	return bls.VerifyPartial(RoundMessage(number, previous), bls.PublicShare(commitments, partial.Index), partial)
+}
+
+//	partial signature of a committee member for round number of an unchained beacon
+func PartialSignUnchained(number uint64, share *bls.KeyShare) *bls.PartialSignature {
+	return bls.PartialSign(UnchainedRoundMessage(number), share)
+}
+
+//	verify partial signature of round number of an unchained beacon
+func VerifyPartialUnchained(number uint64, commitments []*g2pubs.PublicKey, partial *bls.PartialSignature) bool {
+	return bls.VerifyPartial(UnchainedRoundMessage(number), bls.PublicShare(commitments, partial.Index), partial)
+}
+
+//	recover the group signature of round number from t partial signatures
+func NewThresholdRound(number uint64, partials []*bls.PartialSignature, t int) (*Round, error) {
+	signature, err := bls.RecoverSignature(partials, t)
+	if err != nil {
+		return nil, err
+	}
+	proof := signature.Serialize()
+	digest := sha256.Sum256(proof[:])
+	return &Round{Number: number, Proof: proof[:], Output: digest[:]}, nil
+}
@@ -0,0 +1,151 @@
+package beacon
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/phoreproject/bls/g2pubs"
+	"go-cryptology/bls"
+	"go-cryptology/ibe"
+)
+
+func TestThresholdChain(t *testing.T) {
+	fmt.Println("Test : beacon chain of a threshold committee ...")
+
+	groupKey, groupPubKey := bls.GenBLSKey()
+	shares, commitments, err := bls.SplitKey(groupKey, 3, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	genesis := []byte("genesis")
+	chain := NewChain(genesis, NewBLSVerifier(groupPubKey), NewMemoryStore())
+
+	t0 := time.Now()
+
+	previous := genesis
+	for i := 0; i < 3; i++ {
+		number, _, err := chain.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		//	a different subset of the committee signs every round
+		var partials []*bls.PartialSignature
+		for _, share := range shares[i : i+3] {
+			partial := PartialSign(number, previous, share)
+			if !VerifyPartial(number, previous, commitments, partial) {
+				t.Fatalf("got result %v but expected %v\n", false, true)
+			}
+			partials = append(partials, partial)
+		}
+
+		round, err := NewThresholdRound(number, partials, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := chain.Append(round); err != nil {
+			t.Fatalf("got result %v but expected %v\n", err, nil)
+		}
+		previous = round.Output
+	}
+
+	//	the output must be the digest of the signature
+	number, _, _ := chain.Next()
+	var partials []*bls.PartialSignature
+	for _, share := range shares[:3] {
+		partials = append(partials, PartialSign(number, previous, share))
+	}
+	round, _ := NewThresholdRound(number, partials, 3)
+	round.Output = append([]byte(nil), round.Output...)
+	round.Output[0] ^= 1
+	if err := chain.Append(round); !errors.Is(err, ErrInvalidOutput) {
+		t.Fatalf("got result %v but expected %v\n", err, ErrInvalidOutput)
+	}
+
+	//	a partial signature over another round is rejected
+	partial := PartialSign(number+1, previous, shares[0])
+	if VerifyPartial(number, previous, commitments, partial) {
+		t.Fatalf("got result %v but expected %v\n", true, false)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func TestUnchainedTimelock(t *testing.T) {
+	fmt.Println("Test : timelock decryption with an unchained beacon round ...")
+
+	beaconKey, beaconPubKey := ibe.Setup()
+	shares, commitments, err := bls.SplitKey(beaconKey, 2, 3)
+	if err != nil {
+		t.Fatalf("split key failed, %v\n", err)
+	}
+	chain := NewUnchainedChain(NewBLSVerifier(beaconPubKey), NewMemoryStore())
+
+	t0 := time.Now()
+
+	//	encrypted before the round is produced
+	message := []byte("sealed bid")
+	ciphertext := ibe.EncryptToRound(message, 2, beaconPubKey)
+
+	var round *Round
+	for i := 0; i < 2; i++ {
+		number, roundMessage, err := chain.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(roundMessage, ibe.RoundIdentity(number)) {
+			t.Fatalf("got message %x but expected %x\n", roundMessage, ibe.RoundIdentity(number))
+		}
+
+		var partials []*bls.PartialSignature
+		for _, share := range shares[i : i+2] {
+			partial := PartialSignUnchained(number, share)
+			if !VerifyPartialUnchained(number, commitments, partial) {
+				t.Fatalf("got result %v but expected %v\n", false, true)
+			}
+			partials = append(partials, partial)
+		}
+		round, err = NewThresholdRound(number, partials, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := chain.Append(round); err != nil {
+			t.Fatalf("got result %v but expected %v\n", err, nil)
+		}
+	}
+
+	//	the proof of round 2 is the IBE key of the round
+	var b [SignatureSize]byte
+	copy(b[:], round.Proof)
+	beaconSignature, err := g2pubs.DeserializeSignature(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := ibe.DecryptWithRound(ciphertext, round.Number, beaconPubKey, beaconSignature)
+	if err != nil || !bytes.Equal(result, message) {
+		t.Fatalf("got message %q but expected %q, %v\n", result, message, err)
+	}
+
+	//	round 1 does not open it
+	first, _ := chain.Get(1)
+	copy(b[:], first.Proof)
+	beaconSignature, _ = g2pubs.DeserializeSignature(b)
+	if _, err := ibe.DecryptWithRound(ciphertext, round.Number, beaconPubKey, beaconSignature); err != ibe.ErrInvalidBeacon {
+		t.Fatalf("got error %v but expected %v\n", err, ibe.ErrInvalidBeacon)
+	}
+
+	//	a round over the chained message is not a round of the unchained beacon
+	var partials []*bls.PartialSignature
+	for _, share := range shares[:2] {
+		partials = append(partials, PartialSign(3, round.Output, share))
+	}
+	chained, _ := NewThresholdRound(3, partials, 2)
+	if err := chain.Append(chained); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("got result %v but expected %v\n", err, ErrInvalidSignature)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
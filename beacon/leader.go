@@ -0,0 +1,26 @@
+package beacon
+
+import (
+	"go-cryptology/vrf"
+)
+
+//	verifier of rounds produced by a single leader with vrf.Prove
+type VRFVerifier struct {
+	pubKey *vrf.PublicKey
+}
+
+//	create verifier of the leader public key
+func NewVRFVerifier(pubKey *vrf.PublicKey) *VRFVerifier {
+	return &VRFVerifier{pubKey: pubKey}
+}
+
+//	the output is the VRF random number
+func (v *VRFVerifier) Verify(message []byte, proof []byte, output []byte) error {
+	return v.pubKey.Verify(message, output, proof)
+}
+
+//	produce round number of the leader on top of the previous output
+func NewVRFRound(number uint64, previous []byte, priKey *vrf.PrivateKey) *Round {
+	output, proof := priKey.Prove(RoundMessage(number, previous))
+	return &Round{Number: number, Proof: proof, Output: output}
+}
@@ -0,0 +1,52 @@
+package beacon
+
+import "sync"
+
+//	persists verified rounds
+type Store interface {
+	Put(round *Round) error
+	Get(number uint64) (*Round, error)
+	//	latest round, ErrNotFound if the store is empty
+	Last() (*Round, error)
+}
+
+//	in-memory store
+type MemoryStore struct {
+	mu     sync.RWMutex
+	rounds map[uint64]*Round
+	last   uint64
+}
+
+//	create empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{rounds: make(map[uint64]*Round)}
+}
+
+func (s *MemoryStore) Put(round *Round) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rounds[round.Number] = round
+	if round.Number > s.last {
+		s.last = round.Number
+	}
+	return nil
+}
+
+func (s *MemoryStore) Get(number uint64) (*Round, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	round, ok := s.rounds[number]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return round, nil
+}
+
+func (s *MemoryStore) Last() (*Round, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.last == 0 {
+		return nil, ErrNotFound
+	}
+	return s.rounds[s.last], nil
+}
@@ -0,0 +1,227 @@
+package bls
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"unsafe"
+
+	"github.com/phoreproject/bls/g2pubs"
+)
+
+var (
+	ErrIndexOutOfRange = errors.New("[BLS] signer index out of range")
+	ErrAlreadySigned   = errors.New("[BLS] signer already contributed")
+	ErrOverlap         = errors.New("[BLS] aggregates have common signers")
+	ErrMismatch        = errors.New("[BLS] aggregates are over different messages or validator sets")
+)
+
+//	registered validator public keys, the signer index is the position in the set
+type ValidatorSet struct {
+	pubKeys []*g2pubs.PublicKey
+}
+
+//	bit i is set if signer i contributed
+type Bitfield []byte
+
+//	aggregate of signatures over one message, built incrementally.
+//	signers sign the same message, so the validators must have proven possession of their keys
+type Aggregator struct {
+	mu         sync.Mutex
+	validators *ValidatorSet
+	message    []byte
+	signers    Bitfield
+	signature  *g2pubs.Signature
+}
+
+//	create validator set
+func NewValidatorSet(pubKeys []*g2pubs.PublicKey) *ValidatorSet {
+	temp := make([]*g2pubs.PublicKey, len(pubKeys))
+	copy(temp, pubKeys)
+	return &ValidatorSet{pubKeys: temp}
+}
+
+//	number of validators
+func (v *ValidatorSet) Size() int {
+	return len(v.pubKeys)
+}
+
+//	public key of validator i
+func (v *ValidatorSet) PubKey(i int) *g2pubs.PublicKey {
+	return v.pubKeys[i]
+}
+
+//	aggregate public key of the validators selected by the bitfield
+func (v *ValidatorSet) AggregatePubKey(signers Bitfield) *g2pubs.PublicKey {
+	aggregatePubKey := g2pubs.NewAggregatePubkey()
+	for i, pubKey := range v.pubKeys {
+		if signers.Get(i) {
+			aggregatePubKey.Aggregate(pubKey)
+		}
+	}
+	return aggregatePubKey
+}
+
+//	create empty bitfield for n signers
+func NewBitfield(n int) Bitfield {
+	return make(Bitfield, (n+7)/8)
+}
+
+//	whether bit i is set
+func (b Bitfield) Get(i int) bool {
+	if i < 0 || i/8 >= len(b) {
+		return false
+	}
+	return b[i/8]&(1<<uint(i%8)) != 0
+}
+
+//	set bit i
+func (b Bitfield) Set(i int) {
+	b[i/8] |= 1 << uint(i%8)
+}
+
+//	number of set bits
+func (b Bitfield) Count() int {
+	count := 0
+	for _, x := range b {
+		for ; x != 0; x &= x - 1 {
+			count++
+		}
+	}
+	return count
+}
+
+//	whether both bitfields have a common bit
+func (b Bitfield) Overlaps(other Bitfield) bool {
+	for i := 0; i < len(b) && i < len(other); i++ {
+		if b[i]&other[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+//	copy bitfield
+func (b Bitfield) Copy() Bitfield {
+	temp := make(Bitfield, len(b))
+	copy(temp, b)
+	return temp
+}
+
+//	create empty aggregator over message for the validator set
+func NewAggregator(validators *ValidatorSet, message []byte) *Aggregator {
+	temp := make([]byte, len(message))
+	copy(temp, message)
+	return &Aggregator{
+		validators: validators,
+		message:    temp,
+		signers:    NewBitfield(validators.Size()),
+		signature:  g2pubs.NewAggregateSignature(),
+	}
+}
+
+//	add the signature of validator index, the signature is not verified
+func (a *Aggregator) Add(index int, signature *g2pubs.Signature) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if index < 0 || index >= a.validators.Size() {
+		return ErrIndexOutOfRange
+	}
+	if a.signers.Get(index) {
+		return ErrAlreadySigned
+	}
+
+	a.signers.Set(index)
+	a.signature.Aggregate(signature)
+	return nil
+}
+
+//	add the signature of validator index after verifying it
+func (a *Aggregator) AddVerified(index int, signature *g2pubs.Signature) (bool, error) {
+	if index < 0 || index >= a.validators.Size() {
+		return false, ErrIndexOutOfRange
+	}
+	if !Verify(a.message, a.validators.PubKey(index), signature) {
+		return false, nil
+	}
+	return true, a.Add(index, signature)
+}
+
+//	merge another aggregate with disjoint signers into this one
+func (a *Aggregator) Merge(other *Aggregator) error {
+	if a == other {
+		return ErrOverlap
+	}
+
+	//	both aggregators are locked together, so the signers and the signature of other
+	//	are one snapshot. locks are taken in address order, concurrent a.Merge(b) and
+	//	b.Merge(a) can not deadlock
+	first, second := a, other
+	if uintptr(unsafe.Pointer(second)) < uintptr(unsafe.Pointer(first)) {
+		first, second = second, first
+	}
+	first.mu.Lock()
+	defer first.mu.Unlock()
+	second.mu.Lock()
+	defer second.mu.Unlock()
+
+	if a.validators != other.validators || !bytes.Equal(a.message, other.message) {
+		return ErrMismatch
+	}
+	if a.signers.Overlaps(other.signers) {
+		return ErrOverlap
+	}
+
+	for i := range a.signers {
+		a.signers[i] |= other.signers[i]
+	}
+	a.signature.Aggregate(other.signature)
+	return nil
+}
+
+//	bitfield of the signers that contributed
+func (a *Aggregator) Signers() Bitfield {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.signers.Copy()
+}
+
+//	current aggregate signature
+func (a *Aggregator) Signature() *g2pubs.Signature {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.signature.Copy()
+}
+
+//	verify aggregate signature against the public keys of the signers,
+//	the bitfield and the signature are copied under one lock so that they match
+func (a *Aggregator) Verify() bool {
+	a.mu.Lock()
+	signers, signature := a.signers.Copy(), a.signature.Copy()
+	a.mu.Unlock()
+
+	if signers.Count() == 0 {
+		return false
+	}
+	return Verify(a.message, a.validators.AggregatePubKey(signers), signature)
+}
+
+//	verify a received aggregate signature and signer bitfield against the validator set
+func VerifyAggregateBitfield(message []byte, validators *ValidatorSet, signers Bitfield, signature *g2pubs.Signature) bool {
+	if len(signers) != len(NewBitfield(validators.Size())) || signers.Count() == 0 {
+		return false
+	}
+
+	//	bits past the last validator must be clear
+	for i := validators.Size(); i < len(signers)*8; i++ {
+		if signers.Get(i) {
+			return false
+		}
+	}
+
+	if signature.GetPoint().IsZero() {
+		return false
+	}
+	return Verify(message, validators.AggregatePubKey(signers), signature)
+}
@@ -0,0 +1,233 @@
+package bls
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/phoreproject/bls/g2pubs"
+)
+
+func makeValidators(n int) ([]*g2pubs.SecretKey, *ValidatorSet) {
+	var priKeys []*g2pubs.SecretKey
+	var pubKeys []*g2pubs.PublicKey
+	for i := 0; i < n; i++ {
+		priKey, pubKey := GenBLSKey()
+		priKeys = append(priKeys, priKey)
+		pubKeys = append(pubKeys, pubKey)
+	}
+	return priKeys, NewValidatorSet(pubKeys)
+}
+
+func TestAggregator(t *testing.T) {
+	fmt.Println("Test : incremental aggregator ...")
+
+	priKeys, validators := makeValidators(10)
+	message := Encode("hello world")
+
+	t0 := time.Now()
+
+	aggregator := NewAggregator(validators, message)
+	for _, i := range []int{0, 3, 9} {
+		if err := aggregator.Add(i, Sign(message, priKeys[i])); err != nil {
+			t.Fatalf("add signature %d failed, %v\n", i, err)
+		}
+	}
+	if err := aggregator.Add(3, Sign(message, priKeys[3])); err != ErrAlreadySigned {
+		t.Fatalf("got error %v but expected %v\n", err, ErrAlreadySigned)
+	}
+	if err := aggregator.Add(10, Sign(message, priKeys[3])); err != ErrIndexOutOfRange {
+		t.Fatalf("got error %v but expected %v\n", err, ErrIndexOutOfRange)
+	}
+
+	signers := aggregator.Signers()
+	if signers.Count() != 3 || !signers.Get(9) || signers.Get(1) {
+		t.Fatalf("got signers %08b\n", signers)
+	}
+
+	result := aggregator.Verify()
+	wanted := true
+	if result != wanted {
+		t.Fatalf("got result %v but expected %v\n", result, wanted)
+	}
+	if !VerifyAggregateBitfield(message, validators, signers, aggregator.Signature()) {
+		t.Fatalf("verify aggregate with bitfield failed\n")
+	}
+
+	//	claiming an extra signer must fail
+	signers.Set(1)
+	if VerifyAggregateBitfield(message, validators, signers, aggregator.Signature()) {
+		t.Fatalf("verify aggregate with wrong bitfield succeeded\n")
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func TestAggregatorMerge(t *testing.T) {
+	fmt.Println("Test : merge disjoint aggregates ...")
+
+	priKeys, validators := makeValidators(6)
+	message := Encode("hello world")
+
+	a := NewAggregator(validators, message)
+	b := NewAggregator(validators, message)
+	for i := 0; i < 6; i++ {
+		target := a
+		if i%2 == 1 {
+			target = b
+		}
+		if ok, err := target.AddVerified(i, Sign(message, priKeys[i])); !ok || err != nil {
+			t.Fatalf("add signature %d failed, %v\n", i, err)
+		}
+	}
+
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("merge failed, %v\n", err)
+	}
+	if a.Signers().Count() != 6 || !a.Verify() {
+		t.Fatalf("merged aggregate failed to verify\n")
+	}
+	if err := a.Merge(b); err != ErrOverlap {
+		t.Fatalf("got error %v but expected %v\n", err, ErrOverlap)
+	}
+	if err := a.Merge(NewAggregator(validators, Encode("other"))); err != ErrMismatch {
+		t.Fatalf("got error %v but expected %v\n", err, ErrMismatch)
+	}
+}
+
+//	run with -race, Merge must see the signers and the signature of one state
+func TestAggregatorConcurrentMerge(t *testing.T) {
+	fmt.Println("Test : merge aggregates while signatures are added ...")
+
+	priKeys, validators := makeValidators(16)
+	message := Encode("hello world")
+	signatures := make([]*g2pubs.Signature, len(priKeys))
+	for i, priKey := range priKeys {
+		signatures[i] = Sign(message, priKey)
+	}
+
+	t0 := time.Now()
+
+	a := NewAggregator(validators, message)
+	b := NewAggregator(validators, message)
+	c := NewAggregator(validators, message)
+	x := NewAggregator(validators, message)
+	y := NewAggregator(validators, message)
+	x.Add(14, signatures[14])
+	y.Add(15, signatures[15])
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 4)
+	for i := 0; i < 12; i++ {
+		target := a
+		if i >= 6 {
+			target = b
+		}
+		wg.Add(1)
+		go func(target *Aggregator, i int) {
+			defer wg.Done()
+			if err := target.Add(i, signatures[i]); err != nil {
+				errs <- err
+			}
+		}(target, i)
+	}
+	for _, pair := range [][2]*Aggregator{{a, b}, {c, b}, {x, y}, {y, x}} {
+		wg.Add(1)
+		go func(dst, src *Aggregator) {
+			defer wg.Done()
+			errs <- dst.Merge(src)
+		}(pair[0], pair[1])
+	}
+	wg.Wait()
+	close(errs)
+
+	//	x and y merged into each other, exactly one of them overlaps
+	overlaps := 0
+	for err := range errs {
+		if err == ErrOverlap {
+			overlaps++
+		} else if err != nil {
+			t.Fatalf("got error %v but expected %v\n", err, nil)
+		}
+	}
+	if overlaps != 1 {
+		t.Fatalf("got %d overlaps but expected 1\n", overlaps)
+	}
+
+	for _, aggregator := range []*Aggregator{a, c, x, y} {
+		if aggregator.Signers().Count() > 0 && !aggregator.Verify() {
+			t.Fatalf("signers %08b do not match the aggregate signature\n", aggregator.Signers())
+		}
+	}
+	if a.Signers().Count() < 6 || b.Signers().Count() != 6 {
+		t.Fatalf("got signers %08b and %08b\n", a.Signers(), b.Signers())
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func TestAggregatorConcurrentVerify(t *testing.T) {
+	fmt.Println("Test : verify aggregate while signatures are added ...")
+
+	priKeys, validators := makeValidators(8)
+	message := Encode("hello world")
+
+	t0 := time.Now()
+
+	a := NewAggregator(validators, message)
+	a.Add(0, Sign(message, priKeys[0]))
+
+	var wg sync.WaitGroup
+	results := make(chan bool, 2*(len(priKeys)-1))
+	for i := 1; i < len(priKeys); i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			a.Add(i, Sign(message, priKeys[i]))
+		}(i)
+		go func() {
+			defer wg.Done()
+			results <- a.Verify()
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	for result := range results {
+		if !result {
+			t.Fatalf("got result %v but expected %v\n", result, true)
+		}
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func TestAggregatorRejectsInvalid(t *testing.T) {
+	fmt.Println("Test : aggregator rejects invalid signature ...")
+
+	priKeys, validators := makeValidators(2)
+	message := Encode("hello world")
+
+	aggregator := NewAggregator(validators, message)
+	if ok, _ := aggregator.AddVerified(0, Sign(message, priKeys[1])); ok {
+		t.Fatalf("added signature of another validator\n")
+	}
+	if aggregator.Verify() {
+		t.Fatalf("empty aggregate verified\n")
+	}
+}
+
+func BenchmarkAggregatorAdd(b *testing.B) {
+	priKeys, validators := makeValidators(1)
+	message := Encode("hello world")
+	signature := Sign(message, priKeys[0])
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		aggregator := NewAggregator(validators, message)
+		if err := aggregator.Add(0, signature); err != nil {
+			b.Fatalf("add signature failed")
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package bls
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"log"
+	"sync"
+
+	bls12 "github.com/phoreproject/bls"
+	"github.com/phoreproject/bls/g2pubs"
+)
+
+//	batch verify independent signatures, each signature is over its own message and public key.
+//	with random r_i it checks e(sum r_i * sig_i, g2) == prod e(r_i * H(m_i), pk_i) as one multi-pairing,
+//	a false result does not tell which signature is invalid
+func BatchVerify(message [][]byte, pubKeys []*g2pubs.PublicKey, signatures []*g2pubs.Signature, opts ...VerifyOption) (bool, error) {
+	if err := checkBatch(message, pubKeys); err != nil {
+		return false, err
+	}
+	if len(signatures) != len(message) {
+		return false, ErrLengthMismatch
+	}
+
+	workers := newVerifyOptions(opts).workers
+	n := len(message)
+	items := make([]bls12.MillerLoopItem, n+1)
+	weighted := make([]*bls12.G1Projective, n)
+	valid := true
+	var mu sync.Mutex
+
+	parallelFor(n, workers, func(i int) {
+		sig, pub := signatures[i].GetPoint(), pubKeys[i].GetPoint()
+		if sig.IsZero() || pub.IsZero() {
+			mu.Lock()
+			valid = false
+			mu.Unlock()
+			return
+		}
+
+		r := randomScalar()
+		weighted[i] = sig.MulFR(r)
+		items[i] = bls12.MillerLoopItem{
+			P: bls12.HashG1(message[i]).MulFR(r).ToAffine(),
+			Q: bls12.G2AffineToPrepared(pub.ToAffine()),
+		}
+	})
+	if !valid {
+		return false, nil
+	}
+
+	aggregate := bls12.G1ProjectiveZero.Copy()
+	for _, w := range weighted {
+		aggregate = aggregate.Add(w)
+	}
+	aggregate.NegAssign()
+	items[n] = bls12.MillerLoopItem{
+		P: aggregate.ToAffine(),
+		Q: bls12.G2AffineToPrepared(bls12.G2AffineOne),
+	}
+
+	return multiPairing(items, workers).Equals(bls12.FQ12One), nil
+}
+
+//	product of the pairings of all items, the Miller loops are split across workers
+//	and the final exponentiation is done once
+func multiPairing(items []bls12.MillerLoopItem, workers int) *bls12.FQ12 {
+	if workers > len(items) {
+		workers = len(items)
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	results := make([]*bls12.FQ12, workers)
+	chunk := (len(items) + workers - 1) / workers
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		start, end := w*chunk, (w+1)*chunk
+		if end > len(items) {
+			end = len(items)
+		}
+		if start >= end {
+			results[w] = bls12.FQ12One.Copy()
+			continue
+		}
+
+		wg.Add(1)
+		go func(w int, part []bls12.MillerLoopItem) {
+			defer wg.Done()
+			results[w] = bls12.MillerLoop(part)
+		}(w, items[start:end])
+	}
+	wg.Wait()
+
+	product := bls12.FQ12One.Copy()
+	for _, result := range results {
+		product.MulAssign(result)
+	}
+	return bls12.FinalExponentiation(product)
+}
+
+//	run f(0..n-1) on a fixed number of goroutines
+func parallelFor(n int, workers int, f func(i int)) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	var wg sync.WaitGroup
+	next := make(chan int)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				f(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+}
+
+//	random non-zero 64-bit scalar for the linear combination
+func randomScalar() *bls12.FRRepr {
+	var b [8]byte
+	for {
+		if _, err := rand.Read(b[:]); err != nil {
+			log.Fatalf("[BLS] generate random scalar failed, %v\n", err)
+		}
+		if r := binary.BigEndian.Uint64(b[:]); r != 0 {
+			return bls12.NewFRRepr(r)
+		}
+	}
+}
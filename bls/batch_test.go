@@ -0,0 +1,76 @@
+package bls
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/phoreproject/bls/g2pubs"
+)
+
+func makeSignatureBatch(n int) (message [][]byte, pubKeys []*g2pubs.PublicKey, sigs []*g2pubs.Signature) {
+	for i := 0; i < n; i++ {
+		priKey, pubKey := GenBLSKey()
+		m := Encode(i)
+		message = append(message, m)
+		pubKeys = append(pubKeys, pubKey)
+		sigs = append(sigs, Sign(m, priKey))
+	}
+	return
+}
+
+func TestBatchVerify(t *testing.T) {
+	fmt.Println("Test : batch verify independent signatures ...")
+
+	message, pubKeys, sigs := makeSignatureBatch(16)
+
+	t0 := time.Now()
+
+	result, err := BatchVerify(message, pubKeys, sigs)
+	wanted := true
+	if result != wanted {
+		t.Fatalf("got result %v but expected %v, %v\n", result, wanted, err)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func TestBatchVerifyInvalidSignature(t *testing.T) {
+	fmt.Println("Test : batch verify fails with one invalid signature ...")
+
+	message, pubKeys, sigs := makeSignatureBatch(8)
+
+	//	swap two signatures, each one is valid on its own
+	sigs[2], sigs[5] = sigs[5], sigs[2]
+
+	result, err := BatchVerify(message, pubKeys, sigs)
+	wanted := false
+	if result != wanted || err != nil {
+		t.Fatalf("got result %v but expected %v, %v\n", result, wanted, err)
+	}
+
+	//	faulty signatures whose errors cancel out in a plain sum
+	message, pubKeys, sigs = makeSignatureBatch(2)
+	priKey, _ := GenBLSKey()
+	delta := Sign(Encode("delta"), priKey).GetPoint()
+	sigs[0] = g2pubs.NewSignatureFromG1(sigs[0].GetPoint().Add(delta).ToAffine())
+	delta.NegAssign()
+	sigs[1] = g2pubs.NewSignatureFromG1(sigs[1].GetPoint().Add(delta).ToAffine())
+
+	result, err = BatchVerify(message, pubKeys, sigs)
+	if result != wanted || err != nil {
+		t.Fatalf("got result %v but expected %v, %v\n", result, wanted, err)
+	}
+}
+
+func TestBatchVerifyLengthMismatch(t *testing.T) {
+	fmt.Println("Test : batch verify rejects mismatched input ...")
+
+	message, pubKeys, sigs := makeSignatureBatch(2)
+	if _, err := BatchVerify(message, pubKeys, sigs[:1]); err != ErrLengthMismatch {
+		t.Fatalf("got error %v but expected %v\n", err, ErrLengthMismatch)
+	}
+	if _, err := BatchVerify(nil, nil, nil); err != ErrNoMessage {
+		t.Fatalf("got error %v but expected %v\n", err, ErrNoMessage)
+	}
+}
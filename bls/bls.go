@@ -2,10 +2,18 @@ package bls
 
 import (
 	"crypto/rand"
+	"errors"
+	"fmt"
 	"github.com/phoreproject/bls/g2pubs"
 	"log"
 )
 
+var (
+	ErrLengthMismatch   = errors.New("[BLS] number of messages and public keys differ")
+	ErrNoMessage        = errors.New("[BLS] no message to verify")
+	ErrDuplicateMessage = errors.New("[BLS] messages of a batch must be distinct")
+)
+
 //	generate BLS private key and public key
 func GenBLSKey() (priKey *g2pubs.SecretKey, pubKey *g2pubs.PublicKey) {
 	priKey, err := g2pubs.RandKey(rand.Reader)
@@ -54,12 +62,69 @@ func AggregateSignatures(sigs []*g2pubs.Signature) *g2pubs.Signature {
 	return g2pubs.AggregateSignatures(temp)
 }
 
-//	verify aggregate signature
-func VerifyAggregate(message []byte, pubKeys []*g2pubs.PublicKey, signature *g2pubs.Signature) bool {
-	return signature.VerifyAggregateCommon(pubKeys, message)
+//	verify aggregate signature, the public keys are summed in parallel
+func VerifyAggregate(message []byte, pubKeys []*g2pubs.PublicKey, signature *g2pubs.Signature, opts ...VerifyOption) bool {
+	if len(pubKeys) == 0 {
+		return false
+	}
+	o := newVerifyOptions(opts)
+	return verifyPairings([][]byte{message}, []*g2pubs.PublicKey{aggregatePubKeys(pubKeys, o.workers)}, signature, o.workers)
+}
+
+//	batch verify aggregate signature, the messages must be distinct
+//	since there is no proof-of-possession of the public keys.
+//	the Miller loops are split across workers and the final exponentiation is done once
+func BatchVerifyAggregate(message [][]byte, pubKeys []*g2pubs.PublicKey, signature *g2pubs.Signature, opts ...VerifyOption) (bool, error) {
+	if err := checkBatch(message, pubKeys); err != nil {
+		return false, err
+	}
+
+	seen := make(map[string]int, len(message))
+	for i, m := range message {
+		if j, ok := seen[string(m)]; ok {
+			return false, fmt.Errorf("%w: message %d duplicates message %d", ErrDuplicateMessage, i, j)
+		}
+		seen[string(m)] = i
+	}
+
+	return verifyPairings(message, pubKeys, signature, newVerifyOptions(opts).workers), nil
+}
+
+//	digital signature with message augmentation, the public key is prepended to the message
+func SignAugmented(message []byte, priKey *g2pubs.SecretKey) *g2pubs.Signature {
+	return Sign(augment(message, g2pubs.PrivToPub(priKey)), priKey)
+}
+
+//	verify signature with message augmentation
+func VerifyAugmented(message []byte, pubKey *g2pubs.PublicKey, signature *g2pubs.Signature) bool {
+	return Verify(augment(message, pubKey), pubKey, signature)
+}
+
+//	batch verify aggregate signature with message augmentation, messages may repeat
+//	but every signer must sign at most once
+func BatchVerifyAggregateAugmented(message [][]byte, pubKeys []*g2pubs.PublicKey, signature *g2pubs.Signature, opts ...VerifyOption) (bool, error) {
+	if err := checkBatch(message, pubKeys); err != nil {
+		return false, err
+	}
+
+	augmented := make([][]byte, len(message))
+	for i := range message {
+		augmented[i] = augment(message[i], pubKeys[i])
+	}
+	return BatchVerifyAggregate(augmented, pubKeys, signature, opts...)
+}
+
+func checkBatch(message [][]byte, pubKeys []*g2pubs.PublicKey) error {
+	if len(message) == 0 {
+		return ErrNoMessage
+	}
+	if len(message) != len(pubKeys) {
+		return ErrLengthMismatch
+	}
+	return nil
 }
 
-//	batch verify aggregate signature
-func BatchVerifyAggregate(message [][]byte, pubKeys []*g2pubs.PublicKey, signature *g2pubs.Signature) bool {
-	return signature.VerifyAggregate(pubKeys, message)
+func augment(message []byte, pubKey *g2pubs.PublicKey) []byte {
+	serialized := pubKey.Serialize()
+	return append(serialized[:], message...)
 }
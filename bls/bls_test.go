@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"crypto/sha256"
 	"encoding/gob"
+	"errors"
 	"fmt"
 	"github.com/phoreproject/bls/g2pubs"
 	"log"
+	"runtime"
 	"testing"
 	"time"
 )
@@ -132,10 +134,78 @@ func TestBatchVerifyAggregateSignature(t *testing.T) {
 
 	aggregateSignature := AggregateSignatures(sigs)
 
-	result := BatchVerifyAggregate(batchmessage, pubKeys, aggregateSignature)
+	result, err := BatchVerifyAggregate(batchmessage, pubKeys, aggregateSignature)
 	wanted := true
 	if result != wanted {
-		t.Fatalf("got result %v but expected %v\n", result, wanted)
+		t.Fatalf("got result %v but expected %v, %v\n", result, wanted, err)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func TestBatchVerifyAggregateDuplicateMessage(t *testing.T) {
+	fmt.Println("Test : batch aggregate signature rejects duplicate messages ...")
+
+	t0 := time.Now()
+
+	message := Encode("hello world")
+
+	var pubKeys []*g2pubs.PublicKey
+	var sigs []*g2pubs.Signature
+	for i := 0; i < 3; i++ {
+		priKey, pubKey := GenBLSKey()
+		pubKeys = append(pubKeys, pubKey)
+		sigs = append(sigs, Sign(message, priKey))
+	}
+	aggregateSignature := AggregateSignatures(sigs)
+
+	result, err := BatchVerifyAggregate([][]byte{message, message, message}, pubKeys, aggregateSignature)
+	if result || !errors.Is(err, ErrDuplicateMessage) {
+		t.Fatalf("got result %v and error %v but expected %v\n", result, err, ErrDuplicateMessage)
+	}
+
+	result, err = BatchVerifyAggregate([][]byte{message}, pubKeys, aggregateSignature)
+	if result || err != ErrLengthMismatch {
+		t.Fatalf("got result %v and error %v but expected %v\n", result, err, ErrLengthMismatch)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func TestBatchVerifyAggregateAugmented(t *testing.T) {
+	fmt.Println("Test : batch aggregate signature with message augmentation ...")
+
+	message := Encode("hello world")
+
+	var pubKeys []*g2pubs.PublicKey
+	var messages [][]byte
+	var sigs []*g2pubs.Signature
+	for i := 0; i < 3; i++ {
+		priKey, pubKey := GenBLSKey()
+		pubKeys = append(pubKeys, pubKey)
+		messages = append(messages, message)
+
+		signature := SignAugmented(message, priKey)
+		if !VerifyAugmented(message, pubKey, signature) {
+			t.Fatalf("augmented signature %d failed to verify\n", i)
+		}
+		sigs = append(sigs, signature)
+	}
+
+	t0 := time.Now()
+
+	aggregateSignature := AggregateSignatures(sigs)
+
+	result, err := BatchVerifyAggregateAugmented(messages, pubKeys, aggregateSignature)
+	wanted := true
+	if result != wanted {
+		t.Fatalf("got result %v but expected %v, %v\n", result, wanted, err)
+	}
+
+	//	the same signer twice is still a duplicate
+	result, err = BatchVerifyAggregateAugmented(messages[:2], []*g2pubs.PublicKey{pubKeys[0], pubKeys[0]}, aggregateSignature)
+	if result || !errors.Is(err, ErrDuplicateMessage) {
+		t.Fatalf("got result %v and error %v but expected %v\n", result, err, ErrDuplicateMessage)
 	}
 
 	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
@@ -176,6 +246,21 @@ func BenchmarkVerify(b *testing.B) {
 	}
 }
 
+func BenchmarkBatchVerify(b *testing.B) {
+	for _, n := range []int{1, 16, 128} {
+		message, pubKeys, sigs := makeSignatureBatch(n)
+
+		b.Run(fmt.Sprintf("%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				result, _ := BatchVerify(message, pubKeys, sigs)
+				if result != true {
+					b.Fatalf("batch verify failed")
+				}
+			}
+		})
+	}
+}
+
 func BenchmarkCommonBLS(b *testing.B) {
 	//	generate BLS key
 	priKey, pubKey := GenBLSKey()
@@ -262,13 +347,46 @@ func BenchmarkBatchVerifyAggregate(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		result := BatchVerifyAggregate(batchmessage, pubKeys, aggregateSignature)
+		result, _ := BatchVerifyAggregate(batchmessage, pubKeys, aggregateSignature)
 		if result != true {
 			b.Fatalf("batch verify aggregate signature failed\n")
 		}
 	}
 }
 
+//	sequential g2pubs verification against the multi-pairing with one worker and with GOMAXPROCS workers
+func BenchmarkBatchVerifyAggregateParallel(b *testing.B) {
+	for _, n := range []int{16, 256} {
+		message, pubKeys, sigs := makeSignatureBatch(n)
+		aggregateSignature := AggregateSignatures(sigs)
+
+		b.Run(fmt.Sprintf("g2pubs/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if !aggregateSignature.VerifyAggregate(pubKeys, message) {
+					b.Fatalf("batch verify aggregate signature failed\n")
+				}
+			}
+		})
+		for _, workers := range benchmarkWorkers() {
+			b.Run(fmt.Sprintf("workers=%d/%d", workers, n), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					result, _ := BatchVerifyAggregate(message, pubKeys, aggregateSignature, WithWorkers(workers))
+					if result != true {
+						b.Fatalf("batch verify aggregate signature failed\n")
+					}
+				}
+			})
+		}
+	}
+}
+
+func benchmarkWorkers() []int {
+	if procs := runtime.GOMAXPROCS(0); procs > 1 {
+		return []int{1, procs}
+	}
+	return []int{1}
+}
+
 func BenchmarkAggregateBLS(b *testing.B) {
 	message := Encode("hello world")
 
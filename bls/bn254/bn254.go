@@ -0,0 +1,284 @@
+package bn254
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+
+	bn256 "github.com/umbracle/go-eth-bn256"
+)
+
+const (
+	SecretKeySize = 32
+	//	uncompressed points in the encoding of the EVM precompiles
+	PublicKeySize = 128
+	SignatureSize = 64
+)
+
+var (
+	ErrLengthMismatch   = errors.New("[BN254] number of messages and public keys differ")
+	ErrNoMessage        = errors.New("[BN254] no message to verify")
+	ErrSecretKey        = errors.New("[BN254] secret key out of range")
+	ErrDuplicateMessage = errors.New("[BN254] messages of a batch must be distinct")
+)
+
+var (
+	//	not an RFC 9380 suite, HashToG1 hashes with plain SHA-256 and try-and-increment
+	hashDomain = []byte("BLS_SIG_BN254G1_SHA-256_TAI_")
+	//	base field modulus p = 36u^4 + 36u^3 + 24u^2 + 6u + 1, u = 4965661367192848881
+	fieldModulus, _ = new(big.Int).SetString("21888242871839275222246405745257275088696311157297823662689037894645226208583", 10)
+	//	p = 3 mod 4, so sqrt(a) = a^((p+1)/4)
+	sqrtExponent = new(big.Int).Rsh(new(big.Int).Add(fieldModulus, big.NewInt(1)), 2)
+	curveB       = big.NewInt(3)
+)
+
+type SecretKey struct {
+	x *big.Int
+}
+
+//	public key in G2
+type PublicKey struct {
+	p *bn256.G2
+}
+
+//	signature in G1
+type Signature struct {
+	s *bn256.G1
+}
+
+//	generate BLS private key and public key
+func GenBLSKey() (priKey *SecretKey, pubKey *PublicKey) {
+	x, p, err := bn256.RandomG2(rand.Reader)
+	if err != nil {
+		log.Fatalf("[BN254] generate secret key failed, %v\n", err)
+	}
+	return &SecretKey{x: x}, &PublicKey{p: p}
+}
+
+//	public key of the secret key
+func PrivToPub(priKey *SecretKey) *PublicKey {
+	return &PublicKey{p: new(bn256.G2).ScalarBaseMult(priKey.x)}
+}
+
+//	digital signature
+func Sign(message []byte, priKey *SecretKey) *Signature {
+	return &Signature{s: new(bn256.G1).ScalarMult(HashToG1(message), priKey.x)}
+}
+
+//	verify signature, e(sig, -g2) * e(H(m), pk) == 1
+func Verify(message []byte, pubKey *PublicKey, signature *Signature) bool {
+	if pubKey.isZero() || signature.isZero() {
+		return false
+	}
+	return pairingCheck(
+		[]*bn256.G1{signature.s, HashToG1(message)},
+		[]*bn256.G2{negG2(), pubKey.p},
+	)
+}
+
+//	aggregate public keys
+func AggregatePubKeys(pubKeys []*PublicKey) *PublicKey {
+	if len(pubKeys) == 0 {
+		log.Fatalf("[BN254] no public key to aggregate\n")
+	}
+
+	aggregatePubKey := new(bn256.G2).Set(pubKeys[0].p)
+	for _, pubKey := range pubKeys[1:] {
+		aggregatePubKey.Add(aggregatePubKey, pubKey.p)
+	}
+	return &PublicKey{p: aggregatePubKey}
+}
+
+//	aggregate signatures
+func AggregateSignatures(sigs []*Signature) *Signature {
+	if len(sigs) == 0 {
+		log.Fatalf("[BN254] no signature to aggregate\n")
+	}
+
+	aggregateSig := new(bn256.G1).Set(sigs[0].s)
+	for _, sig := range sigs[1:] {
+		aggregateSig.Add(aggregateSig, sig.s)
+	}
+	return &Signature{s: aggregateSig}
+}
+
+//	verify aggregate signature over a common message
+func VerifyAggregate(message []byte, pubKeys []*PublicKey, signature *Signature) bool {
+	if len(pubKeys) == 0 {
+		return false
+	}
+	return Verify(message, AggregatePubKeys(pubKeys), signature)
+}
+
+//	batch verify aggregate signature, the messages must be distinct
+//	since there is no proof-of-possession of the public keys
+func BatchVerifyAggregate(message [][]byte, pubKeys []*PublicKey, signature *Signature) (bool, error) {
+	if len(message) == 0 {
+		return false, ErrNoMessage
+	}
+	if len(message) != len(pubKeys) {
+		return false, ErrLengthMismatch
+	}
+
+	seen := make(map[string]int, len(message))
+	for i, m := range message {
+		if j, ok := seen[string(m)]; ok {
+			return false, fmt.Errorf("%w: message %d duplicates message %d", ErrDuplicateMessage, i, j)
+		}
+		seen[string(m)] = i
+	}
+
+	if signature.isZero() {
+		return false, nil
+	}
+	g1 := []*bn256.G1{signature.s}
+	g2 := []*bn256.G2{negG2()}
+	for i := range message {
+		if pubKeys[i].isZero() {
+			return false, nil
+		}
+		g1 = append(g1, HashToG1(message[i]))
+		g2 = append(g2, pubKeys[i].p)
+	}
+	return pairingCheck(g1, g2), nil
+}
+
+//	hash message to G1 by try-and-increment:
+//	x = SHA256(domain || counter || SHA256(message)) mod p, until x^3 + 3 is a square.
+//	the cofactor of G1 is 1, the root y = (x^3 + 3)^((p+1)/4) is used as is.
+//	it is not constant time, but it is cheap to reproduce in a contract
+func HashToG1(message []byte) *bn256.G1 {
+	digest := sha256.Sum256(message)
+
+	var counter [4]byte
+	x, y, y2 := new(big.Int), new(big.Int), new(big.Int)
+	for i := uint32(0); ; i++ {
+		binary.BigEndian.PutUint32(counter[:], i)
+		h := sha256.New()
+		h.Write(hashDomain)
+		h.Write(counter[:])
+		h.Write(digest[:])
+		x.SetBytes(h.Sum(nil))
+		x.Mod(x, fieldModulus)
+
+		y2.Exp(x, big.NewInt(3), fieldModulus)
+		y2.Add(y2, curveB)
+		y2.Mod(y2, fieldModulus)
+		y.Exp(y2, sqrtExponent, fieldModulus)
+		if new(big.Int).Exp(y, big.NewInt(2), fieldModulus).Cmp(y2) != 0 {
+			continue
+		}
+
+		buf := make([]byte, 64)
+		copy(buf[32-len(x.Bytes()):32], x.Bytes())
+		copy(buf[64-len(y.Bytes()):], y.Bytes())
+		point := new(bn256.G1)
+		if _, err := point.Unmarshal(buf); err == nil {
+			return point
+		}
+	}
+}
+
+//	serialize secret key as 32 bytes big-endian
+func (k *SecretKey) Serialize() []byte {
+	buf := make([]byte, SecretKeySize)
+	b := k.x.Bytes()
+	copy(buf[SecretKeySize-len(b):], b)
+	return buf
+}
+
+//	deserialize secret key, it must be in [1, order)
+func DeserializeSecretKey(b []byte) (*SecretKey, error) {
+	x := new(big.Int).SetBytes(b)
+	if len(b) != SecretKeySize || x.Sign() == 0 || x.Cmp(bn256.Order) >= 0 {
+		return nil, ErrSecretKey
+	}
+	return &SecretKey{x: x}, nil
+}
+
+//	serialize public key as x.im || x.re || y.im || y.re
+func (p *PublicKey) Serialize() []byte {
+	return p.p.Marshal()
+}
+
+//	deserialize public key, the point must be on the curve and in G2
+func DeserializePublicKey(b []byte) (*PublicKey, error) {
+	if len(b) != PublicKeySize {
+		return nil, fmt.Errorf("[BN254] public key must be %d bytes", PublicKeySize)
+	}
+	p := new(bn256.G2)
+	if _, err := p.Unmarshal(b); err != nil {
+		return nil, err
+	}
+	return &PublicKey{p: p}, nil
+}
+
+//	G2 point of the public key
+func (p *PublicKey) GetPoint() *bn256.G2 {
+	return p.p
+}
+
+//	serialize signature as x || y
+func (s *Signature) Serialize() []byte {
+	return s.s.Marshal()
+}
+
+//	deserialize signature, the point must be on the curve
+func DeserializeSignature(b []byte) (*Signature, error) {
+	if len(b) != SignatureSize {
+		return nil, fmt.Errorf("[BN254] signature must be %d bytes", SignatureSize)
+	}
+	s := new(bn256.G1)
+	if _, err := s.Unmarshal(b); err != nil {
+		return nil, err
+	}
+	return &Signature{s: s}, nil
+}
+
+//	G1 point of the signature
+func (s *Signature) GetPoint() *bn256.G1 {
+	return s.s
+}
+
+func (p *PublicKey) isZero() bool {
+	return isZero(p.p.Marshal())
+}
+
+func (s *Signature) isZero() bool {
+	return isZero(s.s.Marshal())
+}
+
+func isZero(b []byte) bool {
+	for _, x := range b {
+		if x != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+//	the Miller loop of PairingCheck expects the affine form produced by Unmarshal,
+//	so every point is re-encoded first
+func pairingCheck(g1 []*bn256.G1, g2 []*bn256.G2) bool {
+	a := make([]*bn256.G1, len(g1))
+	b := make([]*bn256.G2, len(g2))
+	for i := range g1 {
+		a[i], b[i] = new(bn256.G1), new(bn256.G2)
+		if _, err := a[i].Unmarshal(g1[i].Marshal()); err != nil {
+			return false
+		}
+		if _, err := b[i].Unmarshal(g2[i].Marshal()); err != nil {
+			return false
+		}
+	}
+	return bn256.PairingCheck(a, b)
+}
+
+func negG2() *bn256.G2 {
+	g := new(bn256.G2).ScalarBaseMult(big.NewInt(1))
+	return g.Neg(g)
+}
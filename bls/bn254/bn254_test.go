@@ -0,0 +1,143 @@
+package bn254
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	fmt.Println("Test : BN254 verify ...")
+
+	priKey, pubKey := GenBLSKey()
+
+	t0 := time.Now()
+
+	message := []byte("hello world")
+	signature := Sign(message, priKey)
+
+	result := Verify(message, pubKey, signature)
+	wanted := true
+	if result != wanted {
+		t.Fatalf("got result %v but expected %v\n", result, wanted)
+	}
+
+	//	wrong message and wrong key
+	result = Verify([]byte("hello"), pubKey, signature)
+	wanted = false
+	if result != wanted {
+		t.Fatalf("got result %v but expected %v\n", result, wanted)
+	}
+	_, pubKey2 := GenBLSKey()
+	result = Verify(message, pubKey2, signature)
+	if result != wanted {
+		t.Fatalf("got result %v but expected %v\n", result, wanted)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func TestVerifyAggregate(t *testing.T) {
+	fmt.Println("Test : BN254 verify aggregate signature ...")
+
+	message := []byte("hello world")
+	var pubKeys []*PublicKey
+	var sigs []*Signature
+	for i := 0; i < 5; i++ {
+		priKey, pubKey := GenBLSKey()
+		pubKeys = append(pubKeys, pubKey)
+		sigs = append(sigs, Sign(message, priKey))
+	}
+
+	t0 := time.Now()
+
+	result := VerifyAggregate(message, pubKeys, AggregateSignatures(sigs))
+	wanted := true
+	if result != wanted {
+		t.Fatalf("got result %v but expected %v\n", result, wanted)
+	}
+
+	result = VerifyAggregate(message, pubKeys[1:], AggregateSignatures(sigs))
+	wanted = false
+	if result != wanted {
+		t.Fatalf("got result %v but expected %v\n", result, wanted)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func TestBatchVerifyAggregate(t *testing.T) {
+	fmt.Println("Test : BN254 batch verify aggregate signature ...")
+
+	var message [][]byte
+	var pubKeys []*PublicKey
+	var sigs []*Signature
+	for i := 0; i < 5; i++ {
+		priKey, pubKey := GenBLSKey()
+		m := []byte(fmt.Sprintf("message %d", i))
+		message = append(message, m)
+		pubKeys = append(pubKeys, pubKey)
+		sigs = append(sigs, Sign(m, priKey))
+	}
+
+	t0 := time.Now()
+
+	result, err := BatchVerifyAggregate(message, pubKeys, AggregateSignatures(sigs))
+	wanted := true
+	if result != wanted {
+		t.Fatalf("got result %v but expected %v, %v\n", result, wanted, err)
+	}
+
+	message[1] = message[0]
+	if _, err := BatchVerifyAggregate(message, pubKeys, AggregateSignatures(sigs)); !errors.Is(err, ErrDuplicateMessage) {
+		t.Fatalf("got result %v but expected %v\n", err, ErrDuplicateMessage)
+	}
+	if _, err := BatchVerifyAggregate(message, pubKeys[1:], AggregateSignatures(sigs)); err != ErrLengthMismatch {
+		t.Fatalf("got result %v but expected %v\n", err, ErrLengthMismatch)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func TestSerialize(t *testing.T) {
+	fmt.Println("Test : BN254 serialize keys and signature ...")
+
+	priKey, pubKey := GenBLSKey()
+	message := []byte("hello world")
+	signature := Sign(message, priKey)
+
+	sk, err := DeserializeSecretKey(priKey.Serialize())
+	if err != nil {
+		t.Fatalf("got result %v but expected %v\n", err, nil)
+	}
+	pk, err := DeserializePublicKey(pubKey.Serialize())
+	if err != nil {
+		t.Fatalf("got result %v but expected %v\n", err, nil)
+	}
+	sig, err := DeserializeSignature(Sign(message, sk).Serialize())
+	if err != nil {
+		t.Fatalf("got result %v but expected %v\n", err, nil)
+	}
+
+	result := Verify(message, pk, sig) && string(sig.Serialize()) == string(signature.Serialize())
+	wanted := true
+	if result != wanted {
+		t.Fatalf("got result %v but expected %v\n", result, wanted)
+	}
+
+	//	the point at infinity deserializes but never verifies
+	zero, err := DeserializeSignature(make([]byte, SignatureSize))
+	if err != nil {
+		t.Fatalf("got result %v but expected %v\n", err, nil)
+	}
+	result = Verify(message, pk, zero)
+	wanted = false
+	if result != wanted {
+		t.Fatalf("got result %v but expected %v\n", result, wanted)
+	}
+
+	if _, err := DeserializeSecretKey(make([]byte, SecretKeySize)); err != ErrSecretKey {
+		t.Fatalf("got result %v but expected %v\n", err, ErrSecretKey)
+	}
+}
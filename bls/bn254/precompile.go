@@ -0,0 +1,93 @@
+package bn254
+
+import (
+	"errors"
+	"math/big"
+
+	bn256 "github.com/umbracle/go-eth-bn256"
+)
+
+//	input sizes of the EIP-196 and EIP-197 precompiles
+const (
+	AddInputSize     = 128
+	MulInputSize     = 96
+	PairingPairSize  = 192
+	PairingInputSize = 2 * PairingPairSize
+)
+
+var ErrPairingInput = errors.New("[BN254] pairing input is not a multiple of 192 bytes")
+
+//	input of the ecPairing precompile (address 0x08) that returns 1 iff the signature is valid
+func PairingInput(message []byte, pubKey *PublicKey, signature *Signature) []byte {
+	input := make([]byte, 0, PairingInputSize)
+	input = append(input, signature.s.Marshal()...)
+	input = append(input, negG2().Marshal()...)
+	input = append(input, HashToG1(message).Marshal()...)
+	return append(input, pubKey.p.Marshal()...)
+}
+
+//	ecAdd (address 0x06), short input is padded with zeros
+func EcAdd(input []byte) ([]byte, error) {
+	input = padInput(input, AddInputSize)
+	x, err := unmarshalG1(input[:64])
+	if err != nil {
+		return nil, err
+	}
+	y, err := unmarshalG1(input[64:128])
+	if err != nil {
+		return nil, err
+	}
+	return new(bn256.G1).Add(x, y).Marshal(), nil
+}
+
+//	ecMul (address 0x07), short input is padded with zeros
+func EcMul(input []byte) ([]byte, error) {
+	input = padInput(input, MulInputSize)
+	p, err := unmarshalG1(input[:64])
+	if err != nil {
+		return nil, err
+	}
+	return new(bn256.G1).ScalarMult(p, new(big.Int).SetBytes(input[64:96])).Marshal(), nil
+}
+
+//	ecPairing (address 0x08), returns 32 bytes encoding 1 or 0
+func EcPairing(input []byte) ([]byte, error) {
+	if len(input)%PairingPairSize != 0 {
+		return nil, ErrPairingInput
+	}
+
+	var g1 []*bn256.G1
+	var g2 []*bn256.G2
+	for i := 0; i < len(input); i += PairingPairSize {
+		p, err := unmarshalG1(input[i : i+64])
+		if err != nil {
+			return nil, err
+		}
+		q := new(bn256.G2)
+		if _, err := q.Unmarshal(input[i+64 : i+PairingPairSize]); err != nil {
+			return nil, err
+		}
+		g1 = append(g1, p)
+		g2 = append(g2, q)
+	}
+
+	result := make([]byte, 32)
+	if bn256.PairingCheck(g1, g2) {
+		result[31] = 1
+	}
+	return result, nil
+}
+
+func unmarshalG1(b []byte) (*bn256.G1, error) {
+	p := new(bn256.G1)
+	if _, err := p.Unmarshal(b); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func padInput(input []byte, size int) []byte {
+	temp := make([]byte, size)
+	copy(temp, input)
+	return temp
+}
@@ -0,0 +1,156 @@
+package bn254
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+)
+
+//	EIP-196 and EIP-197 vectors from the go-ethereum precompile tests, core/vm/testdata/precompiles
+var ecAddVectors = []struct {
+	name, input, expected string
+}{
+	{
+		"chfast1",
+		"18b18acfb4c2c30276db5411368e7185b311dd124691610c5d3b74034e093dc9063c909c4720840cb5134cb9f59fa749755796819658d32efc0d288198f3726607c2b7f58a84bd6145f00c9c2bc0bb1a187f20ff2c92963a88019e7c6a014eed06614e20c147e940f2d70da3f74c9a17df361706a4485c742bd6788478fa17d7",
+		"2243525c5efd4b9c3d3c45ac0ca3fe4dd85e830a4ce6b65fa1eeaee202839703301d1d33be6da8e509df21cc35964723180eed7532537db9ae5e7d48f195c915",
+	},
+	{
+		"chfast2",
+		"2243525c5efd4b9c3d3c45ac0ca3fe4dd85e830a4ce6b65fa1eeaee202839703301d1d33be6da8e509df21cc35964723180eed7532537db9ae5e7d48f195c91518b18acfb4c2c30276db5411368e7185b311dd124691610c5d3b74034e093dc9063c909c4720840cb5134cb9f59fa749755796819658d32efc0d288198f37266",
+		"2bd3e6d0f3b142924f5ca7b49ce5b9d54c4703d7ae5648e61d02268b1a0a9fb721611ce0a6af85915e2f1d70300909ce2e49dfad4a4619c8390cae66cefdb204",
+	},
+	{
+		"cdetrio1",
+		"",
+		"00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+	},
+	{
+		"cdetrio11",
+		"0000000000000000000000000000000000000000000000000000000000000001000000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002",
+		"030644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd315ed738c0e0a7c92e7845f96b2ae9c0a68a6a449e3538fc7ff3ebf7a5a18a2c4",
+	},
+}
+
+var ecMulVectors = []struct {
+	name, input, expected string
+}{
+	{
+		"chfast1",
+		"2bd3e6d0f3b142924f5ca7b49ce5b9d54c4703d7ae5648e61d02268b1a0a9fb721611ce0a6af85915e2f1d70300909ce2e49dfad4a4619c8390cae66cefdb20400000000000000000000000000000000000000000000000011138ce750fa15c2",
+		"070a8d6a982153cae4be29d434e8faef8a47b274a053f5a4ee2a6c9c13c31e5c031b8ce914eba3a9ffb989f9cdd5b0f01943074bf4f0f315690ec3cec6981afc",
+	},
+	{
+		"chfast2",
+		"070a8d6a982153cae4be29d434e8faef8a47b274a053f5a4ee2a6c9c13c31e5c031b8ce914eba3a9ffb989f9cdd5b0f01943074bf4f0f315690ec3cec6981afc30644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd46",
+		"025a6f4181d2b4ea8b724290ffb40156eb0adb514c688556eb79cdea0752c2bb2eff3f31dea215f1eb86023a133a996eb6300b44da664d64251d05381bb8a02e",
+	},
+	{
+		"cdetrio1",
+		"1a87b0584ce92f4593d161480614f2989035225609f08058ccfa3d0f940febe31a2f3c951f6dadcc7ee9007dff81504b0fcd6d7cf59996efdc33d92bf7f9f8f6ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		"2cde5879ba6f13c0b5aa4ef627f159a3347df9722efce88a9afbb20b763b4c411aa7e43076f6aee272755a7f9b84832e71559ba0d2e0b17d5f9f01755e5b0d11",
+	},
+	{
+		"cdetrio11",
+		"039730ea8dff1254c0fee9c0ea777d29a9c710b7e616683f194f18c43b43b869073a5ffcc6fc7a28c30723d6e58ce577356982d65b833a5a5c15bf9024b43d98ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		"00a1a234d08efaa2616607e31eca1980128b00b415c845ff25bba3afcb81dc00242077290ed33906aeb8e42fd98c41bcb9057ba03421af3f2d08cfc441186024",
+	},
+}
+
+var ecPairingVectors = []struct {
+	name, input, expected string
+}{
+	{
+		"jeff1",
+		"1c76476f4def4bb94541d57ebba1193381ffa7aa76ada664dd31c16024c43f593034dd2920f673e204fee2811c678745fc819b55d3e9d294e45c9b03a76aef41209dd15ebff5d46c4bd888e51a93cf99a7329636c63514396b4a452003a35bf704bf11ca01483bfa8b34b43561848d28905960114c8ac04049af4b6315a416782bb8324af6cfc93537a2ad1a445cfd0ca2a71acd7ac41fadbf933c2a51be344d120a2a4cf30c1bf9845f20c6fe39e07ea2cce61f0c9bb048165fe5e4de877550111e129f1cf1097710d41c4ac70fcdfa5ba2023c6ff1cbeac322de49d1b6df7c2032c61a830e3c17286de9462bf242fca2883585b93870a73853face6a6bf411198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c21800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa",
+		"0000000000000000000000000000000000000000000000000000000000000001",
+	},
+	{
+		"jeff2",
+		"2eca0c7238bf16e83e7a1e6c5d49540685ff51380f309842a98561558019fc0203d3260361bb8451de5ff5ecd17f010ff22f5c31cdf184e9020b06fa5997db841213d2149b006137fcfb23036606f848d638d576a120ca981b5b1a5f9300b3ee2276cf730cf493cd95d64677bbb75fc42db72513a4c1e387b476d056f80aa75f21ee6226d31426322afcda621464d0611d226783262e21bb3bc86b537e986237096df1f82dff337dd5972e32a8ad43e28a78a96a823ef1cd4debe12b6552ea5f06967a1237ebfeca9aaae0d6d0bab8e28c198c5a339ef8a2407e31cdac516db922160fa257a5fd5b280642ff47b65eca77e626cb685c84fa6d3b6882a283ddd1198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c21800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa",
+		"0000000000000000000000000000000000000000000000000000000000000001",
+	},
+	{
+		"empty_data",
+		"",
+		"0000000000000000000000000000000000000000000000000000000000000001",
+	},
+	{
+		"one_point",
+		"00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c21800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa",
+		"0000000000000000000000000000000000000000000000000000000000000000",
+	},
+	{
+		"two_point_match_2",
+		"00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c21800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed090689d0585ff075ec9e99ad690c3395bc4b313370b38ef355acdadcd122975b12c85ea5db8c6deb4aab71808dcb408fe3d1e7690c43d37b4ce6cc0166fa7daa00000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002198e9393920d483a7260bfb731fb5d25f1aa493335a9e71297e485b7aef312c21800deef121f1e76426a00665e5c4479674322d4f75edadd46debd5cd992f6ed275dc4a288d1afb3cbb1ac09187524c7db36395df7be3b99e673b13a075a65ec1d9befcd05a5323e6da4d435f3b617cdb3af83285c2df711ef39c01571827f9d",
+		"0000000000000000000000000000000000000000000000000000000000000001",
+	},
+}
+
+func TestPrecompileVectors(t *testing.T) {
+	fmt.Println("Test : BN254 precompile test vectors ...")
+
+	t0 := time.Now()
+
+	check := func(name string, f func([]byte) ([]byte, error), input string, expected string) {
+		in, _ := hex.DecodeString(input)
+		out, err := f(in)
+		if err != nil {
+			t.Fatalf("%s: got result %v but expected %v\n", name, err, nil)
+		}
+		if hex.EncodeToString(out) != expected {
+			t.Fatalf("%s: got result %x but expected %v\n", name, out, expected)
+		}
+	}
+	for _, v := range ecAddVectors {
+		check("ecAdd "+v.name, EcAdd, v.input, v.expected)
+	}
+	for _, v := range ecMulVectors {
+		check("ecMul "+v.name, EcMul, v.input, v.expected)
+	}
+	for _, v := range ecPairingVectors {
+		check("ecPairing "+v.name, EcPairing, v.input, v.expected)
+	}
+
+	if _, err := EcPairing(make([]byte, 100)); err != ErrPairingInput {
+		t.Fatalf("got result %v but expected %v\n", err, ErrPairingInput)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func TestPairingInput(t *testing.T) {
+	fmt.Println("Test : BN254 signature verifies through the ecPairing precompile ...")
+
+	priKey, pubKey := GenBLSKey()
+	message := []byte("hello world")
+	signature := Sign(message, priKey)
+
+	t0 := time.Now()
+
+	input := PairingInput(message, pubKey, signature)
+	if len(input) != PairingInputSize {
+		t.Fatalf("got result %v but expected %v\n", len(input), PairingInputSize)
+	}
+	out, err := EcPairing(input)
+	wanted := append(make([]byte, 31), 1)
+	if err != nil || !bytes.Equal(out, wanted) {
+		t.Fatalf("got result %x but expected %x, %v\n", out, wanted, err)
+	}
+
+	out, _ = EcPairing(PairingInput([]byte("hello"), pubKey, signature))
+	wanted = make([]byte, 32)
+	if !bytes.Equal(out, wanted) {
+		t.Fatalf("got result %x but expected %x\n", out, wanted)
+	}
+
+	//	the signature is ecMul(H(m), sk) over the precompile encodings
+	sig, err := EcMul(append(HashToG1(message).Marshal(), priKey.Serialize()...))
+	if err != nil || !bytes.Equal(sig, signature.Serialize()) {
+		t.Fatalf("got result %x but expected %x, %v\n", sig, signature.Serialize(), err)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
@@ -0,0 +1,145 @@
+package bls
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/phoreproject/bls/g2pubs"
+	"golang.org/x/crypto/hkdf"
+)
+
+var (
+	ErrSeedTooShort = errors.New("[BLS] seed must be at least 32 bytes")
+	ErrInvalidPath  = errors.New("[BLS] invalid key derivation path")
+)
+
+const (
+	//	EIP-2334 purpose and coin type
+	Purpose  = 12381
+	CoinType = 3600
+
+	hkdfModROutputSize = 48
+	lamportChunks      = 255
+)
+
+//	EIP-2333 master secret key from seed
+func DeriveMasterKey(seed []byte) (*g2pubs.SecretKey, error) {
+	if len(seed) < 32 {
+		return nil, ErrSeedTooShort
+	}
+	return ScalarToKey(hkdfModR(seed, nil)), nil
+}
+
+//	EIP-2333 child secret key at index, every derivation is hardened
+func DeriveChildKey(parent *g2pubs.SecretKey, index uint32) *g2pubs.SecretKey {
+	return ScalarToKey(hkdfModR(parentToLamportPK(parent, index), nil))
+}
+
+//	derive secret key from seed along an EIP-2334 path such as m/12381/3600/0/0/0
+func DeriveKeyFromPath(seed []byte, path string) (*g2pubs.SecretKey, error) {
+	indices, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := DeriveMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	for _, index := range indices {
+		key = DeriveChildKey(key, index)
+	}
+	return key, nil
+}
+
+//	parse an EIP-2334 path, the first level after m must be the purpose 12381
+func ParsePath(path string) ([]uint32, error) {
+	levels := strings.Split(path, "/")
+	if len(levels) < 2 || levels[0] != "m" {
+		return nil, ErrInvalidPath
+	}
+
+	indices := make([]uint32, 0, len(levels)-1)
+	for _, level := range levels[1:] {
+		index, err := strconv.ParseUint(level, 10, 32)
+		if err != nil {
+			return nil, ErrInvalidPath
+		}
+		indices = append(indices, uint32(index))
+	}
+	if indices[0] != Purpose {
+		return nil, ErrInvalidPath
+	}
+	return indices, nil
+}
+
+//	EIP-2334 path of the withdrawal key of validator i
+func WithdrawalKeyPath(i uint32) string {
+	return "m/" + strconv.Itoa(Purpose) + "/" + strconv.Itoa(CoinType) + "/" + strconv.FormatUint(uint64(i), 10) + "/0"
+}
+
+//	EIP-2334 path of the signing key of validator i
+func SigningKeyPath(i uint32) string {
+	return WithdrawalKeyPath(i) + "/0"
+}
+
+//	HKDF_mod_r, the salt is re-hashed until the key is not zero
+func hkdfModR(ikm []byte, keyInfo []byte) *big.Int {
+	salt := []byte("BLS-SIG-KEYGEN-SALT-")
+	input := append(append([]byte(nil), ikm...), 0)
+
+	var info [2]byte
+	binary.BigEndian.PutUint16(info[:], hkdfModROutputSize)
+
+	sk := new(big.Int)
+	for sk.Sign() == 0 {
+		digest := sha256.Sum256(salt)
+		salt = digest[:]
+
+		okm := make([]byte, hkdfModROutputSize)
+		reader := hkdf.New(sha256.New, input, salt, append(append([]byte(nil), keyInfo...), info[:]...))
+		if _, err := io.ReadFull(reader, okm); err != nil {
+			panic(err)
+		}
+		sk.SetBytes(okm)
+		sk.Mod(sk, order)
+	}
+	return sk
+}
+
+//	compressed Lamport public key of the parent key, the input of the child derivation
+func parentToLamportPK(parent *g2pubs.SecretKey, index uint32) []byte {
+	var salt [4]byte
+	binary.BigEndian.PutUint32(salt[:], index)
+
+	ikm := parent.Serialize()
+	notIKM := make([]byte, len(ikm))
+	for i := range ikm {
+		notIKM[i] = ^ikm[i]
+	}
+
+	h := sha256.New()
+	for _, chunk := range append(ikmToLamportSK(ikm[:], salt[:]), ikmToLamportSK(notIKM, salt[:])...) {
+		digest := sha256.Sum256(chunk)
+		h.Write(digest[:])
+	}
+	return h.Sum(nil)
+}
+
+func ikmToLamportSK(ikm []byte, salt []byte) [][]byte {
+	okm := make([]byte, 32*lamportChunks)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, ikm, salt, nil), okm); err != nil {
+		panic(err)
+	}
+
+	chunks := make([][]byte, lamportChunks)
+	for i := range chunks {
+		chunks[i] = okm[32*i : 32*(i+1)]
+	}
+	return chunks
+}
@@ -0,0 +1,100 @@
+package bls
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+)
+
+//	test vectors from EIP-2333
+var eip2333Cases = []struct {
+	seed       string
+	masterSK   string
+	childIndex uint32
+	childSK    string
+}{
+	{
+		"c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		"6083874454709270928345386274498605044986640685124978867557563392430687146096",
+		0,
+		"20397789859736650942317412262472558107875392172444076792671091975210932703118",
+	},
+	{
+		"3141592653589793238462643383279502884197169399375105820974944592",
+		"29757020647961307431480504535336562678282505419141012933316116377660817309383",
+		3141592653,
+		"25457201688850691947727629385191704516744796114925897962676248250929345014287",
+	},
+	{
+		"0099FF991111002299DD7744EE3355BBDD8844115566CC55663355668888CC00",
+		"27580842291869792442942448775674722299803720648445448686099262467207037398656",
+		4294967295,
+		"29358610794459428860402234341874281240803786294062035874021252734817515685787",
+	},
+	{
+		"d4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3",
+		"19022158461524446591288038168518313374041767046816487870552872741050760015818",
+		42,
+		"31372231650479070279774297061823572166496564838472787488249775572789064611981",
+	},
+}
+
+func TestDeriveKey(t *testing.T) {
+	fmt.Println("Test : EIP-2333 key derivation ...")
+
+	t0 := time.Now()
+
+	for i, c := range eip2333Cases {
+		seed, _ := hex.DecodeString(c.seed)
+		master, err := DeriveMasterKey(seed)
+		if err != nil {
+			t.Fatalf("case %d: derive master key failed, %v\n", i, err)
+		}
+		wanted, _ := new(big.Int).SetString(c.masterSK, 10)
+		if KeyToScalar(master).Cmp(wanted) != 0 {
+			t.Fatalf("case %d: got master key %v but expected %v\n", i, KeyToScalar(master), wanted)
+		}
+
+		child := DeriveChildKey(master, c.childIndex)
+		wanted, _ = new(big.Int).SetString(c.childSK, 10)
+		if KeyToScalar(child).Cmp(wanted) != 0 {
+			t.Fatalf("case %d: got child key %v but expected %v\n", i, KeyToScalar(child), wanted)
+		}
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func TestDeriveKeyFromPath(t *testing.T) {
+	fmt.Println("Test : EIP-2334 path derivation ...")
+
+	seed, _ := hex.DecodeString(eip2333Cases[0].seed)
+	key, err := DeriveKeyFromPath(seed, SigningKeyPath(0))
+	if err != nil {
+		t.Fatalf("derive key failed, %v\n", err)
+	}
+
+	master, _ := DeriveMasterKey(seed)
+	wanted := master
+	for _, index := range []uint32{12381, 3600, 0, 0, 0} {
+		wanted = DeriveChildKey(wanted, index)
+	}
+	if key.Serialize() != wanted.Serialize() {
+		t.Fatalf("path derivation differs from step by step derivation\n")
+	}
+
+	if SigningKeyPath(7) != "m/12381/3600/7/0/0" || WithdrawalKeyPath(7) != "m/12381/3600/7/0" {
+		t.Fatalf("got paths %s and %s\n", SigningKeyPath(7), WithdrawalKeyPath(7))
+	}
+
+	for _, path := range []string{"", "m", "m/44/60/0", "m/12381/x", "x/12381", "m/12381/4294967296"} {
+		if _, err := ParsePath(path); err != ErrInvalidPath {
+			t.Fatalf("path %q: got error %v but expected %v\n", path, err, ErrInvalidPath)
+		}
+	}
+	if _, err := DeriveMasterKey(seed[:31]); err != ErrSeedTooShort {
+		t.Fatalf("got error %v but expected %v\n", err, ErrSeedTooShort)
+	}
+}
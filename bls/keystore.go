@@ -0,0 +1,260 @@
+package bls
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	bls12 "github.com/phoreproject/bls"
+	"github.com/phoreproject/bls/g2pubs"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	KDFScrypt = "scrypt"
+	KDFPBKDF2 = "pbkdf2"
+
+	keystoreVersion = 4
+)
+
+var (
+	ErrWrongPassword       = errors.New("[BLS] keystore checksum mismatch, wrong password")
+	ErrUnsupportedKeystore = errors.New("[BLS] unsupported keystore")
+)
+
+//	EIP-2335 keystore
+type Keystore struct {
+	Crypto      KeystoreCrypto `json:"crypto"`
+	Description string         `json:"description"`
+	PubKey      string         `json:"pubkey"`
+	Path        string         `json:"path"`
+	UUID        string         `json:"uuid"`
+	Version     int            `json:"version"`
+}
+
+type KeystoreCrypto struct {
+	KDF      KeystoreModule `json:"kdf"`
+	Checksum KeystoreModule `json:"checksum"`
+	Cipher   KeystoreModule `json:"cipher"`
+}
+
+type KeystoreModule struct {
+	Function string          `json:"function"`
+	Params   json.RawMessage `json:"params"`
+	Message  string          `json:"message"`
+}
+
+type scryptParams struct {
+	DKLen int    `json:"dklen"`
+	N     int    `json:"n"`
+	P     int    `json:"p"`
+	R     int    `json:"r"`
+	Salt  string `json:"salt"`
+}
+
+type pbkdf2Params struct {
+	DKLen int    `json:"dklen"`
+	C     int    `json:"c"`
+	PRF   string `json:"prf"`
+	Salt  string `json:"salt"`
+}
+
+type cipherParams struct {
+	IV string `json:"iv"`
+}
+
+//	encrypt secret key into a keystore, kdf is KDFScrypt or KDFPBKDF2
+func EncryptKeystore(priKey *g2pubs.SecretKey, password string, path string, kdf string) (*Keystore, error) {
+	var salt [32]byte
+	var iv [16]byte
+	var id [16]byte
+	for _, b := range [][]byte{salt[:], iv[:], id[:]} {
+		if _, err := io.ReadFull(rand.Reader, b); err != nil {
+			return nil, err
+		}
+	}
+
+	var kdfModule KeystoreModule
+	var params interface{}
+	switch kdf {
+	case KDFScrypt:
+		params = scryptParams{DKLen: 32, N: 262144, P: 1, R: 8, Salt: hex.EncodeToString(salt[:])}
+	case KDFPBKDF2:
+		params = pbkdf2Params{DKLen: 32, C: 262144, PRF: "hmac-sha256", Salt: hex.EncodeToString(salt[:])}
+	default:
+		return nil, ErrUnsupportedKeystore
+	}
+	raw, _ := json.Marshal(params)
+	kdfModule = KeystoreModule{Function: kdf, Params: raw}
+
+	key, err := deriveKeystoreKey(kdfModule, password)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := priKey.Serialize()
+	message := make([]byte, len(secret))
+	if err := aes128CTR(key[:16], iv[:], message, secret[:]); err != nil {
+		return nil, err
+	}
+
+	raw, _ = json.Marshal(cipherParams{IV: hex.EncodeToString(iv[:])})
+	pubKey := keystorePubKey(priKey)
+
+	// RFC 4122 version 4
+	id[6] = id[6]&0x0f | 0x40
+	id[8] = id[8]&0x3f | 0x80
+
+	return &Keystore{
+		Crypto: KeystoreCrypto{
+			KDF: kdfModule,
+			Checksum: KeystoreModule{
+				Function: "sha256",
+				Params:   json.RawMessage("{}"),
+				Message:  hex.EncodeToString(keystoreChecksum(key, message)),
+			},
+			Cipher: KeystoreModule{
+				Function: "aes-128-ctr",
+				Params:   raw,
+				Message:  hex.EncodeToString(message),
+			},
+		},
+		PubKey:  hex.EncodeToString(pubKey[:]),
+		Path:    path,
+		UUID:    fmt.Sprintf("%x-%x-%x-%x-%x", id[0:4], id[4:6], id[6:8], id[8:10], id[10:]),
+		Version: keystoreVersion,
+	}, nil
+}
+
+//	load keystore from JSON
+func LoadKeystore(data []byte) (*Keystore, error) {
+	keystore := new(Keystore)
+	if err := json.Unmarshal(data, keystore); err != nil {
+		return nil, err
+	}
+	if keystore.Version != keystoreVersion {
+		return nil, ErrUnsupportedKeystore
+	}
+	return keystore, nil
+}
+
+//	encode keystore as JSON
+func (k *Keystore) Marshal() ([]byte, error) {
+	return json.MarshalIndent(k, "", "  ")
+}
+
+//	decrypt secret key from the keystore
+func (k *Keystore) Decrypt(password string) (*g2pubs.SecretKey, error) {
+	if k.Crypto.Checksum.Function != "sha256" || k.Crypto.Cipher.Function != "aes-128-ctr" {
+		return nil, ErrUnsupportedKeystore
+	}
+
+	key, err := deriveKeystoreKey(k.Crypto.KDF, password)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := hex.DecodeString(k.Crypto.Cipher.Message)
+	if err != nil {
+		return nil, ErrUnsupportedKeystore
+	}
+	checksum, err := hex.DecodeString(k.Crypto.Checksum.Message)
+	if err != nil {
+		return nil, ErrUnsupportedKeystore
+	}
+	if subtle.ConstantTimeCompare(checksum, keystoreChecksum(key, message)) != 1 {
+		return nil, ErrWrongPassword
+	}
+
+	var params cipherParams
+	if err := json.Unmarshal(k.Crypto.Cipher.Params, &params); err != nil {
+		return nil, ErrUnsupportedKeystore
+	}
+	iv, err := hex.DecodeString(params.IV)
+	if err != nil || len(iv) != aes.BlockSize || len(message) != 32 {
+		return nil, ErrUnsupportedKeystore
+	}
+
+	var secret [32]byte
+	if err := aes128CTR(key[:16], iv, secret[:], message); err != nil {
+		return nil, err
+	}
+	if new(big.Int).SetBytes(secret[:]).Cmp(order) >= 0 {
+		return nil, ErrUnsupportedKeystore
+	}
+	return g2pubs.DeserializeSecretKey(secret), nil
+}
+
+//	decryption key from the KDF module
+func deriveKeystoreKey(module KeystoreModule, password string) ([]byte, error) {
+	switch module.Function {
+	case KDFScrypt:
+		var params scryptParams
+		if err := json.Unmarshal(module.Params, &params); err != nil {
+			return nil, ErrUnsupportedKeystore
+		}
+		salt, err := hex.DecodeString(params.Salt)
+		if err != nil || params.DKLen < 32 {
+			return nil, ErrUnsupportedKeystore
+		}
+		return scrypt.Key(processPassword(password), salt, params.N, params.R, params.P, params.DKLen)
+	case KDFPBKDF2:
+		var params pbkdf2Params
+		if err := json.Unmarshal(module.Params, &params); err != nil {
+			return nil, ErrUnsupportedKeystore
+		}
+		salt, err := hex.DecodeString(params.Salt)
+		if err != nil || params.DKLen < 32 || params.PRF != "hmac-sha256" {
+			return nil, ErrUnsupportedKeystore
+		}
+		return pbkdf2.Key(processPassword(password), salt, params.C, params.DKLen, sha256.New), nil
+	default:
+		return nil, ErrUnsupportedKeystore
+	}
+}
+
+//	NFKD normalization, then strip the C0, C1 and Delete control codes
+func processPassword(password string) []byte {
+	var result []rune
+	for _, r := range norm.NFKD.String(password) {
+		if r <= 0x1f || (r >= 0x7f && r <= 0x9f) {
+			continue
+		}
+		result = append(result, r)
+	}
+	return []byte(string(result))
+}
+
+//	SHA256(DK[16:32] | cipher message)
+func keystoreChecksum(key []byte, message []byte) []byte {
+	h := sha256.New()
+	h.Write(key[16:32])
+	h.Write(message)
+	return h.Sum(nil)
+}
+
+func aes128CTR(key []byte, iv []byte, dst []byte, src []byte) error {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return err
+	}
+	cipher.NewCTR(block, iv).XORKeyStream(dst, src)
+	return nil
+}
+
+//	EIP-2335 records the 48-byte G1 public key of the eth2 scheme, priKey * G1 in compressed form.
+//	the public keys of this package are in G2 and can not be used in its place
+func keystorePubKey(priKey *g2pubs.SecretKey) [48]byte {
+	pubKey := bls12.G1ProjectiveOne.MulFR(priKey.GetFRElement().ToRepr())
+	return bls12.CompressG1(pubKey.ToAffine())
+}
@@ -0,0 +1,149 @@
+package bls
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+)
+
+//	test vectors from EIP-2335
+const (
+	keystorePassword = "\U0001d531\U0001d522\U0001d530\U0001d531\U0001d52d\U0001d51e\U0001d530\U0001d530\U0001d534\U0001d52c\U0001d52f\U0001d521\U0001f511"
+	keystoreSecret   = "000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f"
+
+	scryptKeystore = `{
+    "crypto": {
+        "kdf": {
+            "function": "scrypt",
+            "params": {
+                "dklen": 32,
+                "n": 262144,
+                "p": 1,
+                "r": 8,
+                "salt": "d4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3"
+            },
+            "message": ""
+        },
+        "checksum": {
+            "function": "sha256",
+            "params": {},
+            "message": "d2217fe5f3e9a1e34581ef8a78f7c9928e436d36dacc5e846690a5581e8ea484"
+        },
+        "cipher": {
+            "function": "aes-128-ctr",
+            "params": {
+                "iv": "264daa3f303d7259501c93d997d84fe6"
+            },
+            "message": "06ae90d55fe0a6e9c5c3bc5b170827b2e5cce3929ed3f116c2811e6366dfe20f"
+        }
+    },
+    "description": "This is a test keystore that uses scrypt to secure the secret.",
+    "pubkey": "9612d7a727c9d0a22e185a1c768478dfe919cada9266988cb32359c11f2b7b27f4ae4040902382ae2910c15e2b420d07",
+    "path": "m/12381/60/3141592653/589793238",
+    "uuid": "1d85ae20-35c5-4611-98e8-aa14a633906f",
+    "version": 4
+}`
+
+	pbkdf2Keystore = `{
+    "crypto": {
+        "kdf": {
+            "function": "pbkdf2",
+            "params": {
+                "dklen": 32,
+                "c": 262144,
+                "prf": "hmac-sha256",
+                "salt": "d4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3"
+            },
+            "message": ""
+        },
+        "checksum": {
+            "function": "sha256",
+            "params": {},
+            "message": "8a9f5d9912ed7e75ea794bc5a89bca5f193721d30868ade6f73043c6ea6febf1"
+        },
+        "cipher": {
+            "function": "aes-128-ctr",
+            "params": {
+                "iv": "264daa3f303d7259501c93d997d84fe6"
+            },
+            "message": "cee03fde2af33149775b7223e7845e4fb2c8ae1792e5f99fe9ecf474cc8c16ad"
+        }
+    },
+    "description": "This is a test keystore that uses PBKDF2 to secure the secret.",
+    "pubkey": "9612d7a727c9d0a22e185a1c768478dfe919cada9266988cb32359c11f2b7b27f4ae4040902382ae2910c15e2b420d07",
+    "path": "m/12381/60/0/0",
+    "uuid": "64625def-3331-4eea-ab6f-782f3ed16a83",
+    "version": 4
+}`
+)
+
+func TestKeystoreVectors(t *testing.T) {
+	fmt.Println("Test : EIP-2335 keystore test vectors ...")
+
+	if hex.EncodeToString(processPassword(keystorePassword)) != "7465737470617373776f7264f09f9491" {
+		t.Fatalf("got password %x\n", processPassword(keystorePassword))
+	}
+
+	for _, data := range []string{scryptKeystore, pbkdf2Keystore} {
+		keystore, err := LoadKeystore([]byte(data))
+		if err != nil {
+			t.Fatalf("load keystore failed, %v\n", err)
+		}
+
+		t0 := time.Now()
+
+		priKey, err := keystore.Decrypt(keystorePassword)
+		if err != nil {
+			t.Fatalf("%s: decrypt keystore failed, %v\n", keystore.Crypto.KDF.Function, err)
+		}
+		secret := priKey.Serialize()
+		if hex.EncodeToString(secret[:]) != keystoreSecret {
+			t.Fatalf("got secret %x but expected %s\n", secret, keystoreSecret)
+		}
+		pubKey := keystorePubKey(priKey)
+		if hex.EncodeToString(pubKey[:]) != keystore.PubKey {
+			t.Fatalf("got pubkey %x but expected %s\n", pubKey, keystore.PubKey)
+		}
+
+		if _, err := keystore.Decrypt("testpassword"); err != ErrWrongPassword {
+			t.Fatalf("got error %v but expected %v\n", err, ErrWrongPassword)
+		}
+
+		fmt.Printf("... Passed %s   time: %v ms\n", keystore.Crypto.KDF.Function, time.Since(t0).Milliseconds())
+	}
+}
+
+func TestKeystoreRoundTrip(t *testing.T) {
+	fmt.Println("Test : EIP-2335 keystore round trip ...")
+
+	priKey, _ := GenBLSKey()
+	keystore, err := EncryptKeystore(priKey, "hello world", SigningKeyPath(0), KDFPBKDF2)
+	if err != nil {
+		t.Fatalf("encrypt keystore failed, %v\n", err)
+	}
+
+	data, err := keystore.Marshal()
+	if err != nil {
+		t.Fatalf("marshal keystore failed, %v\n", err)
+	}
+	loaded, err := LoadKeystore(data)
+	if err != nil {
+		t.Fatalf("load keystore failed, %v\n", err)
+	}
+
+	result, err := loaded.Decrypt("hello world")
+	if err != nil {
+		t.Fatalf("decrypt keystore failed, %v\n", err)
+	}
+	if result.Serialize() != priKey.Serialize() {
+		t.Fatalf("decrypted secret key differs\n")
+	}
+	if loaded.Path != SigningKeyPath(0) || len(loaded.UUID) != 36 || len(loaded.PubKey) != 96 {
+		t.Fatalf("got path %s and uuid %s\n", loaded.Path, loaded.UUID)
+	}
+
+	if _, err := EncryptKeystore(priKey, "hello world", "", "argon2"); err != ErrUnsupportedKeystore {
+		t.Fatalf("got error %v but expected %v\n", err, ErrUnsupportedKeystore)
+	}
+}
@@ -0,0 +1,96 @@
+package bls
+
+import (
+	"runtime"
+
+	bls12 "github.com/phoreproject/bls"
+	"github.com/phoreproject/bls/g2pubs"
+)
+
+//	option of the parallel verifiers
+type VerifyOption func(*verifyOptions)
+
+type verifyOptions struct {
+	workers int
+}
+
+//	number of goroutines evaluating Miller loops and hashing messages,
+//	n <= 0 means runtime.GOMAXPROCS(0), which is the default
+func WithWorkers(n int) VerifyOption {
+	return func(o *verifyOptions) {
+		o.workers = n
+	}
+}
+
+func newVerifyOptions(opts []VerifyOption) *verifyOptions {
+	o := &verifyOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.workers <= 0 {
+		o.workers = runtime.GOMAXPROCS(0)
+	}
+	return o
+}
+
+//	e(sig, g2) == prod e(H(m_i), pk_i), the pairing items are built in parallel
+//	and evaluated as one multi-pairing with a single final exponentiation
+func verifyPairings(message [][]byte, pubKeys []*g2pubs.PublicKey, signature *g2pubs.Signature, workers int) bool {
+	sig := signature.GetPoint()
+	if sig.IsZero() {
+		return false
+	}
+
+	n := len(message)
+	items := make([]bls12.MillerLoopItem, n+1)
+	valid := make([]bool, n)
+	parallelFor(n, workers, func(i int) {
+		pub := pubKeys[i].GetPoint()
+		if pub.IsZero() {
+			return
+		}
+		items[i] = bls12.MillerLoopItem{
+			P: bls12.HashG1(message[i]),
+			Q: bls12.G2AffineToPrepared(pub.ToAffine()),
+		}
+		valid[i] = true
+	})
+	for _, v := range valid {
+		if !v {
+			return false
+		}
+	}
+
+	neg := sig.Copy()
+	neg.NegAssign()
+	items[n] = bls12.MillerLoopItem{
+		P: neg.ToAffine(),
+		Q: bls12.G2AffineToPrepared(bls12.G2AffineOne),
+	}
+	return multiPairing(items, workers).Equals(bls12.FQ12One)
+}
+
+//	sum of public keys, partial sums are computed in parallel
+func aggregatePubKeys(pubKeys []*g2pubs.PublicKey, workers int) *g2pubs.PublicKey {
+	if workers > len(pubKeys) {
+		workers = len(pubKeys)
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	partials := make([]*g2pubs.PublicKey, workers)
+	chunk := (len(pubKeys) + workers - 1) / workers
+	parallelFor(workers, workers, func(w int) {
+		partials[w] = g2pubs.NewAggregatePubkey()
+		for i := w * chunk; i < (w+1)*chunk && i < len(pubKeys); i++ {
+			partials[w].Aggregate(pubKeys[i])
+		}
+	})
+
+	aggregatePubKey := g2pubs.NewAggregatePubkey()
+	for _, partial := range partials {
+		aggregatePubKey.Aggregate(partial)
+	}
+	return aggregatePubKey
+}
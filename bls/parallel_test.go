@@ -0,0 +1,90 @@
+package bls
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/phoreproject/bls/g2pubs"
+)
+
+func TestParallelBatchVerifyAggregate(t *testing.T) {
+	fmt.Println("Test : batch verify aggregate signature with different numbers of workers ...")
+
+	message, pubKeys, sigs := makeSignatureBatch(24)
+	aggregateSignature := AggregateSignatures(sigs)
+	badSignature := AggregateSignatures(sigs[1:])
+
+	t0 := time.Now()
+
+	for _, workers := range []int{0, 1, 3, 64} {
+		result, err := BatchVerifyAggregate(message, pubKeys, aggregateSignature, WithWorkers(workers))
+		wanted := aggregateSignature.VerifyAggregate(pubKeys, message)
+		if result != wanted || err != nil {
+			t.Fatalf("got result %v but expected %v, %v\n", result, wanted, err)
+		}
+
+		result, err = BatchVerifyAggregate(message, pubKeys, badSignature, WithWorkers(workers))
+		wanted = false
+		if result != wanted || err != nil {
+			t.Fatalf("got result %v but expected %v, %v\n", result, wanted, err)
+		}
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func TestParallelVerifyAggregate(t *testing.T) {
+	fmt.Println("Test : verify aggregate signature over a common message with different numbers of workers ...")
+
+	message := Encode("hello world")
+	var pubKeys []*g2pubs.PublicKey
+	var sigs []*g2pubs.Signature
+	for i := 0; i < 10; i++ {
+		priKey, pubKey := GenBLSKey()
+		pubKeys = append(pubKeys, pubKey)
+		sigs = append(sigs, Sign(message, priKey))
+	}
+	aggregateSignature := AggregateSignatures(sigs)
+
+	t0 := time.Now()
+
+	for _, workers := range []int{0, 1, 4, 64} {
+		result := VerifyAggregate(message, pubKeys, aggregateSignature, WithWorkers(workers))
+		wanted := true
+		if result != wanted {
+			t.Fatalf("got result %v but expected %v\n", result, wanted)
+		}
+
+		result = VerifyAggregate(message, pubKeys[1:], aggregateSignature, WithWorkers(workers))
+		wanted = false
+		if result != wanted {
+			t.Fatalf("got result %v but expected %v\n", result, wanted)
+		}
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func TestParallelVerifyZeroPoints(t *testing.T) {
+	fmt.Println("Test : parallel verifiers reject points at infinity ...")
+
+	message, pubKeys, sigs := makeSignatureBatch(2)
+
+	result, _ := BatchVerifyAggregate(message, pubKeys, g2pubs.NewAggregateSignature())
+	wanted := false
+	if result != wanted {
+		t.Fatalf("got result %v but expected %v\n", result, wanted)
+	}
+
+	pubKeys[1] = g2pubs.NewAggregatePubkey()
+	result, _ = BatchVerifyAggregate(message, pubKeys, AggregateSignatures(sigs))
+	if result != wanted {
+		t.Fatalf("got result %v but expected %v\n", result, wanted)
+	}
+
+	result = VerifyAggregate(message[0], nil, sigs[0])
+	if result != wanted {
+		t.Fatalf("got result %v but expected %v\n", result, wanted)
+	}
+}
@@ -0,0 +1,261 @@
+package bls
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/phoreproject/bls/g2pubs"
+	"go-cryptology/bls/bn254"
+)
+
+//	pairing-friendly curve of a BLS scheme
+type Curve int
+
+const (
+	BLS12381 Curve = iota
+	//	alt_bn128, the curve of the EVM precompiles
+	BN254
+)
+
+var (
+	ErrUnsupportedCurve = errors.New("[BLS] unsupported curve")
+	ErrMalformedKey     = errors.New("[BLS] malformed key or signature")
+)
+
+//	BLS signature scheme over serialized keys and signatures, the curve is selected at construction
+type Scheme interface {
+	Curve() Curve
+	GenBLSKey() (priKey []byte, pubKey []byte)
+	Sign(message []byte, priKey []byte) ([]byte, error)
+	Verify(message []byte, pubKey []byte, signature []byte) bool
+	AggregatePubKeys(pubKeys [][]byte) ([]byte, error)
+	AggregateSignatures(sigs [][]byte) ([]byte, error)
+	VerifyAggregate(message []byte, pubKeys [][]byte, signature []byte) bool
+	BatchVerifyAggregate(message [][]byte, pubKeys [][]byte, signature []byte) (bool, error)
+}
+
+//	create scheme over curve
+func NewScheme(curve Curve) (Scheme, error) {
+	switch curve {
+	case BLS12381:
+		return bls12381Scheme{}, nil
+	case BN254:
+		return bn254Scheme{}, nil
+	default:
+		return nil, ErrUnsupportedCurve
+	}
+}
+
+type bls12381Scheme struct{}
+
+func (bls12381Scheme) Curve() Curve {
+	return BLS12381
+}
+
+func (bls12381Scheme) GenBLSKey() ([]byte, []byte) {
+	priKey, pubKey := GenBLSKey()
+	sk, pk := priKey.Serialize(), pubKey.Serialize()
+	return sk[:], pk[:]
+}
+
+func (bls12381Scheme) Sign(message []byte, priKey []byte) ([]byte, error) {
+	var b [32]byte
+	if len(priKey) != len(b) {
+		return nil, ErrMalformedKey
+	}
+	copy(b[:], priKey)
+	sig := Sign(message, g2pubs.DeserializeSecretKey(b)).Serialize()
+	return sig[:], nil
+}
+
+func (bls12381Scheme) Verify(message []byte, pubKey []byte, signature []byte) bool {
+	pk, err := deserializePubKey(pubKey)
+	if err != nil {
+		return false
+	}
+	sig, err := deserializeSignature(signature)
+	if err != nil {
+		return false
+	}
+	return Verify(message, pk, sig)
+}
+
+func (bls12381Scheme) AggregatePubKeys(pubKeys [][]byte) ([]byte, error) {
+	pks, err := deserializePubKeys(pubKeys)
+	if err != nil {
+		return nil, err
+	}
+	pk := AggregatePubKeys(pks).Serialize()
+	return pk[:], nil
+}
+
+func (bls12381Scheme) AggregateSignatures(sigs [][]byte) ([]byte, error) {
+	if len(sigs) == 0 {
+		return nil, ErrNoMessage
+	}
+	temp := make([]*g2pubs.Signature, len(sigs))
+	for i := range sigs {
+		sig, err := deserializeSignature(sigs[i])
+		if err != nil {
+			return nil, err
+		}
+		temp[i] = sig
+	}
+	sig := AggregateSignatures(temp).Serialize()
+	return sig[:], nil
+}
+
+func (bls12381Scheme) VerifyAggregate(message []byte, pubKeys [][]byte, signature []byte) bool {
+	pks, err := deserializePubKeys(pubKeys)
+	if err != nil {
+		return false
+	}
+	sig, err := deserializeSignature(signature)
+	if err != nil {
+		return false
+	}
+	return VerifyAggregate(message, pks, sig)
+}
+
+func (bls12381Scheme) BatchVerifyAggregate(message [][]byte, pubKeys [][]byte, signature []byte) (bool, error) {
+	pks, err := deserializePubKeys(pubKeys)
+	if err != nil {
+		return false, err
+	}
+	sig, err := deserializeSignature(signature)
+	if err != nil {
+		return false, err
+	}
+	return BatchVerifyAggregate(message, pks, sig)
+}
+
+func deserializePubKey(b []byte) (*g2pubs.PublicKey, error) {
+	var temp [96]byte
+	if len(b) != len(temp) {
+		return nil, ErrMalformedKey
+	}
+	copy(temp[:], b)
+	return g2pubs.DeserializePublicKey(temp)
+}
+
+func deserializePubKeys(pubKeys [][]byte) ([]*g2pubs.PublicKey, error) {
+	if len(pubKeys) == 0 {
+		return nil, ErrNoMessage
+	}
+	temp := make([]*g2pubs.PublicKey, len(pubKeys))
+	for i := range pubKeys {
+		pk, err := deserializePubKey(pubKeys[i])
+		if err != nil {
+			return nil, err
+		}
+		temp[i] = pk
+	}
+	return temp, nil
+}
+
+func deserializeSignature(b []byte) (*g2pubs.Signature, error) {
+	var temp [48]byte
+	if len(b) != len(temp) {
+		return nil, ErrMalformedKey
+	}
+	copy(temp[:], b)
+	return g2pubs.DeserializeSignature(temp)
+}
+
+type bn254Scheme struct{}
+
+func (bn254Scheme) Curve() Curve {
+	return BN254
+}
+
+func (bn254Scheme) GenBLSKey() ([]byte, []byte) {
+	priKey, pubKey := bn254.GenBLSKey()
+	return priKey.Serialize(), pubKey.Serialize()
+}
+
+func (bn254Scheme) Sign(message []byte, priKey []byte) ([]byte, error) {
+	sk, err := bn254.DeserializeSecretKey(priKey)
+	if err != nil {
+		return nil, err
+	}
+	return bn254.Sign(message, sk).Serialize(), nil
+}
+
+func (bn254Scheme) Verify(message []byte, pubKey []byte, signature []byte) bool {
+	pk, err := bn254.DeserializePublicKey(pubKey)
+	if err != nil {
+		return false
+	}
+	sig, err := bn254.DeserializeSignature(signature)
+	if err != nil {
+		return false
+	}
+	return bn254.Verify(message, pk, sig)
+}
+
+func (bn254Scheme) AggregatePubKeys(pubKeys [][]byte) ([]byte, error) {
+	pks, err := deserializeBN254PubKeys(pubKeys)
+	if err != nil {
+		return nil, err
+	}
+	return bn254.AggregatePubKeys(pks).Serialize(), nil
+}
+
+func (bn254Scheme) AggregateSignatures(sigs [][]byte) ([]byte, error) {
+	if len(sigs) == 0 {
+		return nil, ErrNoMessage
+	}
+	temp := make([]*bn254.Signature, len(sigs))
+	for i := range sigs {
+		sig, err := bn254.DeserializeSignature(sigs[i])
+		if err != nil {
+			return nil, err
+		}
+		temp[i] = sig
+	}
+	return bn254.AggregateSignatures(temp).Serialize(), nil
+}
+
+func (bn254Scheme) VerifyAggregate(message []byte, pubKeys [][]byte, signature []byte) bool {
+	pks, err := deserializeBN254PubKeys(pubKeys)
+	if err != nil {
+		return false
+	}
+	sig, err := bn254.DeserializeSignature(signature)
+	if err != nil {
+		return false
+	}
+	return bn254.VerifyAggregate(message, pks, sig)
+}
+
+func (bn254Scheme) BatchVerifyAggregate(message [][]byte, pubKeys [][]byte, signature []byte) (bool, error) {
+	pks, err := deserializeBN254PubKeys(pubKeys)
+	if err != nil {
+		return false, err
+	}
+	sig, err := bn254.DeserializeSignature(signature)
+	if err != nil {
+		return false, err
+	}
+	result, err := bn254.BatchVerifyAggregate(message, pks, sig)
+	//	the same sentinel on both curves
+	if errors.Is(err, bn254.ErrDuplicateMessage) {
+		return false, fmt.Errorf("%w: %v", ErrDuplicateMessage, err)
+	}
+	return result, err
+}
+
+func deserializeBN254PubKeys(pubKeys [][]byte) ([]*bn254.PublicKey, error) {
+	if len(pubKeys) == 0 {
+		return nil, ErrNoMessage
+	}
+	temp := make([]*bn254.PublicKey, len(pubKeys))
+	for i := range pubKeys {
+		pk, err := bn254.DeserializePublicKey(pubKeys[i])
+		if err != nil {
+			return nil, err
+		}
+		temp[i] = pk
+	}
+	return temp, nil
+}
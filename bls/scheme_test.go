@@ -0,0 +1,131 @@
+package bls
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestScheme(t *testing.T) {
+	for _, curve := range []Curve{BLS12381, BN254} {
+		fmt.Printf("Test : scheme over curve %d ...\n", curve)
+
+		scheme, err := NewScheme(curve)
+		if err != nil {
+			t.Fatalf("got result %v but expected %v\n", err, nil)
+		}
+
+		t0 := time.Now()
+
+		var message [][]byte
+		var priKeys, pubKeys, sigs [][]byte
+		for i := 0; i < 3; i++ {
+			priKey, pubKey := scheme.GenBLSKey()
+			priKeys = append(priKeys, priKey)
+			pubKeys = append(pubKeys, pubKey)
+			message = append(message, Encode(i))
+
+			sig, err := scheme.Sign(message[i], priKey)
+			if err != nil {
+				t.Fatalf("got result %v but expected %v\n", err, nil)
+			}
+			sigs = append(sigs, sig)
+		}
+
+		result := scheme.Verify(message[0], pubKeys[0], sigs[0])
+		wanted := true
+		if result != wanted {
+			t.Fatalf("got result %v but expected %v\n", result, wanted)
+		}
+
+		signature, _ := scheme.AggregateSignatures(sigs)
+		result, err = scheme.BatchVerifyAggregate(message, pubKeys, signature)
+		if result != wanted {
+			t.Fatalf("got result %v but expected %v, %v\n", result, wanted, err)
+		}
+
+		common := Encode("hello world")
+		sigs = sigs[:0]
+		for _, priKey := range priKeys {
+			sig, _ := scheme.Sign(common, priKey)
+			sigs = append(sigs, sig)
+		}
+		signature, _ = scheme.AggregateSignatures(sigs)
+		result = scheme.VerifyAggregate(common, pubKeys, signature)
+		if result != wanted {
+			t.Fatalf("got result %v but expected %v\n", result, wanted)
+		}
+
+		aggregatePubKey, _ := scheme.AggregatePubKeys(pubKeys)
+		result = scheme.Verify(common, aggregatePubKey, signature)
+		if result != wanted {
+			t.Fatalf("got result %v but expected %v\n", result, wanted)
+		}
+
+		result = scheme.Verify(message[1], pubKeys[0], sigs[0])
+		wanted = false
+		if result != wanted {
+			t.Fatalf("got result %v but expected %v\n", result, wanted)
+		}
+
+		//	duplicate messages are reported with the same error on every curve
+		duplicate := [][]byte{message[0], message[1], message[0]}
+		if _, err := scheme.BatchVerifyAggregate(duplicate, pubKeys, signature); !errors.Is(err, ErrDuplicateMessage) {
+			t.Fatalf("got result %v but expected %v\n", err, ErrDuplicateMessage)
+		}
+
+		fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+	}
+
+	if _, err := NewScheme(Curve(-1)); err != ErrUnsupportedCurve {
+		t.Fatalf("got result %v but expected %v\n", err, ErrUnsupportedCurve)
+	}
+}
+
+func TestSchemeRejectsOtherCurve(t *testing.T) {
+	fmt.Println("Test : scheme rejects keys and signatures of the other curve ...")
+
+	bls12381, _ := NewScheme(BLS12381)
+	bn254, _ := NewScheme(BN254)
+
+	t0 := time.Now()
+
+	message := Encode("hello world")
+	for _, pair := range [][2]Scheme{{bls12381, bn254}, {bn254, bls12381}} {
+		signer, verifier := pair[0], pair[1]
+
+		priKey, pubKey := signer.GenBLSKey()
+		signature, err := signer.Sign(message, priKey)
+		if err != nil {
+			t.Fatalf("got result %v but expected %v\n", err, nil)
+		}
+
+		//	the signature only verifies under the scheme that produced it
+		otherPriKey, otherPubKey := verifier.GenBLSKey()
+		otherSignature, _ := verifier.Sign(message, otherPriKey)
+		for _, v := range []struct{ pubKey, signature []byte }{{pubKey, signature}, {otherPubKey, signature}, {pubKey, otherSignature}} {
+			result := verifier.Verify(message, v.pubKey, v.signature)
+			wanted := false
+			if result != wanted {
+				t.Fatalf("curve %d: got result %v but expected %v\n", verifier.Curve(), result, wanted)
+			}
+			result = verifier.VerifyAggregate(message, [][]byte{v.pubKey}, v.signature)
+			if result != wanted {
+				t.Fatalf("curve %d: got result %v but expected %v\n", verifier.Curve(), result, wanted)
+			}
+		}
+
+		if _, err := verifier.AggregateSignatures([][]byte{otherSignature, signature}); err == nil {
+			t.Fatalf("curve %d: got result %v but expected an error\n", verifier.Curve(), err)
+		}
+		if _, err := verifier.AggregatePubKeys([][]byte{otherPubKey, pubKey}); err == nil {
+			t.Fatalf("curve %d: got result %v but expected an error\n", verifier.Curve(), err)
+		}
+		if result, err := verifier.BatchVerifyAggregate([][]byte{message}, [][]byte{pubKey}, signature); result || err == nil {
+			t.Fatalf("curve %d: got result %v but expected an error\n", verifier.Curve(), result)
+		}
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
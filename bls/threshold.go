@@ -0,0 +1,182 @@
+package bls
+
+import (
+	"crypto/rand"
+	"errors"
+	"log"
+	"math/big"
+
+	bls12 "github.com/phoreproject/bls"
+	"github.com/phoreproject/bls/g2pubs"
+)
+
+var (
+	ErrInvalidThreshold  = errors.New("[BLS] threshold must satisfy 0 < t <= n")
+	ErrNotEnoughPartials = errors.New("[BLS] not enough partial signatures to recover")
+	ErrDuplicateIndex    = errors.New("[BLS] duplicate share index")
+	ErrInvalidIndex      = errors.New("[BLS] share index must not be zero")
+)
+
+//	order of the BLS12-381 scalar field
+var order = bls12.RFieldModulus.ToBig()
+
+//	secret key share of a t-of-n threshold group, index starts from 1
+type KeyShare struct {
+	Index  uint32
+	PriKey *g2pubs.SecretKey
+	PubKey *g2pubs.PublicKey
+}
+
+//	signature made by a single key share
+type PartialSignature struct {
+	Index     uint32
+	Signature *g2pubs.Signature
+}
+
+//	split private key into n Shamir shares, any t of them can recover the group signature,
+//	the commitments to the polynomial coefficients are returned to verify the shares
+func SplitKey(priKey *g2pubs.SecretKey, t int, n int) (shares []*KeyShare, commitments []*g2pubs.PublicKey, err error) {
+	if t <= 0 || t > n {
+		return nil, nil, ErrInvalidThreshold
+	}
+
+	coefficients := make([]*big.Int, t)
+	coefficients[0] = KeyToScalar(priKey)
+	for i := 1; i < t; i++ {
+		coefficients[i], err = rand.Int(rand.Reader, order)
+		if err != nil {
+			log.Fatalf("[BLS] generate polynomial coefficient failed, %v\n", err)
+		}
+	}
+
+	commitments = make([]*g2pubs.PublicKey, t)
+	for i, coefficient := range coefficients {
+		commitments[i] = g2pubs.PrivToPub(ScalarToKey(coefficient))
+	}
+
+	shares = make([]*KeyShare, n)
+	for i := 0; i < n; i++ {
+		index := uint32(i + 1)
+		share := ScalarToKey(EvalPolynomial(coefficients, index))
+		shares[i] = &KeyShare{
+			Index:  index,
+			PriKey: share,
+			PubKey: g2pubs.PrivToPub(share),
+		}
+	}
+
+	return shares, commitments, nil
+}
+
+//	sign message with a key share
+func PartialSign(message []byte, share *KeyShare) *PartialSignature {
+	return &PartialSignature{
+		Index:     share.Index,
+		Signature: Sign(message, share.PriKey),
+	}
+}
+
+//	verify partial signature against the public key of the share
+func VerifyPartial(message []byte, pubKey *g2pubs.PublicKey, partial *PartialSignature) bool {
+	return Verify(message, pubKey, partial.Signature)
+}
+
+//	compute the public key of the share at index from the polynomial commitments
+func PublicShare(commitments []*g2pubs.PublicKey, index uint32) *g2pubs.PublicKey {
+	x := new(big.Int).SetUint64(uint64(index))
+
+	// Horner's rule in the exponent
+	result := bls12.G2ProjectiveZero.Copy()
+	for i := len(commitments) - 1; i >= 0; i-- {
+		result = result.MulFR(scalarToRepr(x)).Add(commitments[i].GetPoint())
+	}
+	return g2pubs.NewPublicKeyFromG2(result.ToAffine())
+}
+
+//	recover group signature from at least t partial signatures using Lagrange interpolation
+func RecoverSignature(partials []*PartialSignature, t int) (*g2pubs.Signature, error) {
+	if t <= 0 {
+		return nil, ErrInvalidThreshold
+	}
+	if len(partials) < t {
+		return nil, ErrNotEnoughPartials
+	}
+
+	indices := make([]uint32, t)
+	for i := 0; i < t; i++ {
+		indices[i] = partials[i].Index
+	}
+	lambdas, err := LagrangeCoefficients(indices)
+	if err != nil {
+		return nil, err
+	}
+
+	result := bls12.G1ProjectiveZero.Copy()
+	for i := 0; i < t; i++ {
+		term := partials[i].Signature.GetPoint().MulFR(scalarToRepr(lambdas[i]))
+		result = result.Add(term)
+	}
+	return g2pubs.NewSignatureFromG1(result.ToAffine()), nil
+}
+
+//	Lagrange coefficients at x = 0 for the given share indices
+func LagrangeCoefficients(indices []uint32) ([]*big.Int, error) {
+	seen := make(map[uint32]bool, len(indices))
+	for _, index := range indices {
+		if index == 0 {
+			return nil, ErrInvalidIndex
+		}
+		if seen[index] {
+			return nil, ErrDuplicateIndex
+		}
+		seen[index] = true
+	}
+
+	lambdas := make([]*big.Int, len(indices))
+	for i, xi := range indices {
+		num, den := big.NewInt(1), big.NewInt(1)
+		for j, xj := range indices {
+			if i == j {
+				continue
+			}
+			num.Mul(num, new(big.Int).SetUint64(uint64(xj)))
+			num.Mod(num, order)
+			diff := new(big.Int).Sub(new(big.Int).SetUint64(uint64(xj)), new(big.Int).SetUint64(uint64(xi)))
+			den.Mul(den, diff)
+			den.Mod(den, order)
+		}
+		den.ModInverse(den, order)
+		lambdas[i] = num.Mul(num, den).Mod(num, order)
+	}
+	return lambdas, nil
+}
+
+//	evaluate polynomial at x, coefficients are in ascending order
+func EvalPolynomial(coefficients []*big.Int, x uint32) *big.Int {
+	bx := new(big.Int).SetUint64(uint64(x))
+	result := new(big.Int)
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		result.Mul(result, bx)
+		result.Add(result, coefficients[i])
+		result.Mod(result, order)
+	}
+	return result
+}
+
+//	convert scalar to secret key
+func ScalarToKey(x *big.Int) *g2pubs.SecretKey {
+	return g2pubs.KeyFromFQRepr(scalarToRepr(x))
+}
+
+//	convert secret key to scalar
+func KeyToScalar(priKey *g2pubs.SecretKey) *big.Int {
+	return priKey.GetFRElement().ToRepr().ToBig()
+}
+
+func scalarToRepr(x *big.Int) *bls12.FRRepr {
+	repr, err := bls12.FRReprFromBigInt(new(big.Int).Mod(x, order))
+	if err != nil {
+		log.Fatalf("[BLS] convert scalar failed, %v\n", err)
+	}
+	return repr
+}
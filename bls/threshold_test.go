@@ -0,0 +1,121 @@
+package bls
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestThresholdSign(t *testing.T) {
+	fmt.Println("Test : threshold sign ...")
+
+	priKey, pubKey := GenBLSKey()
+	shares, commitments, err := SplitKey(priKey, 3, 5)
+	if err != nil {
+		t.Fatalf("split key failed, %v\n", err)
+	}
+
+	t0 := time.Now()
+
+	message := Encode("hello world")
+
+	var partials []*PartialSignature
+	for _, i := range []int{4, 0, 2} {
+		partial := PartialSign(message, shares[i])
+		if !VerifyPartial(message, PublicShare(commitments, shares[i].Index), partial) {
+			t.Fatalf("partial signature %d failed to verify\n", shares[i].Index)
+		}
+		partials = append(partials, partial)
+	}
+
+	signature, err := RecoverSignature(partials, 3)
+	if err != nil {
+		t.Fatalf("recover signature failed, %v\n", err)
+	}
+
+	result := Verify(message, pubKey, signature)
+	wanted := true
+	if result != wanted {
+		t.Fatalf("got result %v but expected %v\n", result, wanted)
+	}
+
+	//	group signature is unique whichever shares are used
+	other, _ := RecoverSignature([]*PartialSignature{
+		PartialSign(message, shares[1]),
+		PartialSign(message, shares[3]),
+		PartialSign(message, shares[0]),
+	}, 3)
+	if other.Serialize() != signature.Serialize() {
+		t.Fatalf("recovered signatures differ\n")
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func TestThresholdNotEnoughPartials(t *testing.T) {
+	fmt.Println("Test : threshold sign fails with too few partials ...")
+
+	priKey, pubKey := GenBLSKey()
+	shares, _, _ := SplitKey(priKey, 3, 5)
+
+	message := Encode("hello world")
+	partials := []*PartialSignature{PartialSign(message, shares[0]), PartialSign(message, shares[1])}
+
+	if _, err := RecoverSignature(partials, 3); err != ErrNotEnoughPartials {
+		t.Fatalf("got error %v but expected %v\n", err, ErrNotEnoughPartials)
+	}
+
+	//	interpolating below threshold gives a wrong signature
+	signature, _ := RecoverSignature(partials, 2)
+	if Verify(message, pubKey, signature) {
+		t.Fatalf("signature recovered from too few shares verified\n")
+	}
+}
+
+func TestThresholdDuplicateIndex(t *testing.T) {
+	fmt.Println("Test : threshold sign rejects duplicate shares ...")
+
+	priKey, _ := GenBLSKey()
+	shares, _, _ := SplitKey(priKey, 2, 3)
+
+	message := Encode("hello world")
+	partial := PartialSign(message, shares[0])
+
+	if _, err := RecoverSignature([]*PartialSignature{partial, partial}, 2); err != ErrDuplicateIndex {
+		t.Fatalf("got error %v but expected %v\n", err, ErrDuplicateIndex)
+	}
+}
+
+func TestPublicShare(t *testing.T) {
+	fmt.Println("Test : public share matches key share ...")
+
+	priKey, pubKey := GenBLSKey()
+	shares, commitments, _ := SplitKey(priKey, 2, 4)
+
+	for _, share := range shares {
+		if !PublicShare(commitments, share.Index).Equals(*share.PubKey) {
+			t.Fatalf("public share %d mismatch\n", share.Index)
+		}
+	}
+	if !commitments[0].Equals(*pubKey) {
+		t.Fatalf("first commitment is not the group public key\n")
+	}
+}
+
+func BenchmarkRecoverSignature(b *testing.B) {
+	priKey, _ := GenBLSKey()
+	shares, _, _ := SplitKey(priKey, 3, 5)
+
+	message := Encode("hello world")
+	var partials []*PartialSignature
+	for _, share := range shares {
+		partials = append(partials, PartialSign(message, share))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := RecoverSignature(partials, 3); err != nil {
+			b.Fatalf("recover signature failed, %v\n", err)
+		}
+	}
+}
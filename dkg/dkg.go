@@ -0,0 +1,417 @@
+package dkg
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+	"math/big"
+	"sort"
+
+	"github.com/phoreproject/bls/g2pubs"
+	"go-cryptology/bls"
+)
+
+var (
+	ErrInvalidThreshold = errors.New("[DKG] threshold must satisfy 0 < t <= n")
+	ErrInvalidIndex     = errors.New("[DKG] participant index must be in 1..n")
+	ErrNotQualified     = errors.New("[DKG] not enough qualified dealers")
+	ErrWrongGroup       = errors.New("[DKG] result belongs to another group")
+)
+
+//	participant of the Pedersen DKG, every participant deals a Feldman VSS
+type Participant struct {
+	group     Group
+	index     uint32
+	threshold int
+	n         int
+	rand      io.Reader
+
+	polynomial   []*big.Int
+	commitments  map[uint32][]Point
+	shares       map[uint32]*big.Int
+	complaints   map[uint32][]uint32
+	disqualified map[uint32]bool
+}
+
+//	output of the DKG for one participant
+type Result struct {
+	Group       Group
+	Index       uint32
+	Share       *big.Int
+	PublicKey   Point
+	Commitments []Point
+	Qualified   []uint32
+}
+
+//	create participant index (1..n) of a t-of-n DKG
+func NewParticipant(group Group, index uint32, t int, n int, rand io.Reader) (*Participant, error) {
+	if t <= 0 || t > n {
+		return nil, ErrInvalidThreshold
+	}
+	if index == 0 || int(index) > n {
+		return nil, ErrInvalidIndex
+	}
+
+	return &Participant{
+		group:        group,
+		index:        index,
+		threshold:    t,
+		n:            n,
+		rand:         rand,
+		commitments:  make(map[uint32][]Point, n),
+		shares:       make(map[uint32]*big.Int, n),
+		complaints:   make(map[uint32][]uint32),
+		disqualified: make(map[uint32]bool),
+	}, nil
+}
+
+//	participant index
+func (p *Participant) Index() uint32 {
+	return p.index
+}
+
+//	round 1: sample a random polynomial, broadcast its commitments and send a share to every participant
+func (p *Participant) Deal() (*DealMessage, []*ShareMessage, error) {
+	p.polynomial = make([]*big.Int, p.threshold)
+	for i := range p.polynomial {
+		coefficient, err := rand.Int(p.rand, p.group.Order())
+		if err != nil {
+			return nil, nil, err
+		}
+		p.polynomial[i] = coefficient
+	}
+
+	commitments := make([]Point, p.threshold)
+	for i, coefficient := range p.polynomial {
+		commitments[i] = p.group.Base().Mul(coefficient)
+	}
+	p.commitments[p.index] = commitments
+
+	shares := make([]*ShareMessage, 0, p.n-1)
+	for i := 1; i <= p.n; i++ {
+		share := evalPolynomial(p.polynomial, uint32(i), p.group.Order())
+		if uint32(i) == p.index {
+			p.shares[p.index] = share
+			continue
+		}
+		shares = append(shares, &ShareMessage{Dealer: p.index, Recipient: uint32(i), Share: share})
+	}
+
+	return &DealMessage{Dealer: p.index, Commitments: commitments}, shares, nil
+}
+
+//	round 2: verify the received shares against the dealers' commitments, complain about invalid ones
+func (p *Participant) ProcessDeals(deals []*DealMessage, shares []*ShareMessage) []*ComplaintMessage {
+	for _, deal := range deals {
+		if deal.Dealer == p.index || !p.validIndex(deal.Dealer) {
+			continue
+		}
+		if _, ok := p.commitments[deal.Dealer]; ok {
+			continue
+		}
+		p.commitments[deal.Dealer] = deal.Commitments
+	}
+	for _, share := range shares {
+		if share.Recipient != p.index || share.Dealer == p.index || !p.validIndex(share.Dealer) {
+			continue
+		}
+		if _, ok := p.shares[share.Dealer]; ok {
+			continue
+		}
+		p.shares[share.Dealer] = share.Share
+	}
+
+	var complaints []*ComplaintMessage
+	for i := 1; i <= p.n; i++ {
+		dealer := uint32(i)
+		if dealer == p.index {
+			continue
+		}
+
+		//	a missing or malformed broadcast is seen by everyone
+		commitments, ok := p.commitments[dealer]
+		if !ok || len(commitments) != p.threshold {
+			p.disqualified[dealer] = true
+			continue
+		}
+
+		share, ok := p.shares[dealer]
+		if !ok || !VerifyShare(p.group, commitments, p.index, share) {
+			delete(p.shares, dealer)
+			complaints = append(complaints, &ComplaintMessage{Complainer: p.index, Dealer: dealer})
+		}
+	}
+	return complaints
+}
+
+//	round 3: record complaints, answer those against this participant by revealing the shares
+func (p *Participant) ProcessComplaints(complaints []*ComplaintMessage) []*JustificationMessage {
+	for _, complaint := range complaints {
+		if !p.validIndex(complaint.Complainer) || !p.validIndex(complaint.Dealer) || complaint.Complainer == complaint.Dealer {
+			continue
+		}
+		if containsIndex(p.complaints[complaint.Dealer], complaint.Complainer) {
+			continue
+		}
+		p.complaints[complaint.Dealer] = append(p.complaints[complaint.Dealer], complaint.Complainer)
+	}
+
+	var justifications []*JustificationMessage
+	for _, complainer := range p.complaints[p.index] {
+		justifications = append(justifications, &JustificationMessage{
+			Dealer:     p.index,
+			Complainer: complainer,
+			Share:      evalPolynomial(p.polynomial, complainer, p.group.Order()),
+		})
+	}
+	return justifications
+}
+
+//	round 4: check the revealed shares, disqualify dealers that failed to justify every complaint
+func (p *Participant) ProcessJustifications(justifications []*JustificationMessage) {
+	revealed := make(map[uint32]map[uint32]*big.Int)
+	for _, justification := range justifications {
+		if revealed[justification.Dealer] == nil {
+			revealed[justification.Dealer] = make(map[uint32]*big.Int)
+		}
+		if _, ok := revealed[justification.Dealer][justification.Complainer]; !ok {
+			revealed[justification.Dealer][justification.Complainer] = justification.Share
+		}
+	}
+
+	for dealer, complainers := range p.complaints {
+		if p.disqualified[dealer] {
+			continue
+		}
+
+		//	revealing t shares would leak the dealer's secret
+		if len(complainers) >= p.threshold {
+			p.disqualified[dealer] = true
+			continue
+		}
+
+		for _, complainer := range complainers {
+			share, ok := revealed[dealer][complainer]
+			if !ok || !VerifyShare(p.group, p.commitments[dealer], complainer, share) {
+				p.disqualified[dealer] = true
+				break
+			}
+			if complainer == p.index {
+				p.shares[dealer] = share
+			}
+		}
+	}
+}
+
+//	combine the shares of the qualified dealers into the final key share
+func (p *Participant) Result() (*Result, error) {
+	var qualified []uint32
+	for i := 1; i <= p.n; i++ {
+		if !p.disqualified[uint32(i)] {
+			qualified = append(qualified, uint32(i))
+		}
+	}
+	if len(qualified) < p.threshold {
+		return nil, ErrNotQualified
+	}
+
+	share := new(big.Int)
+	commitments := make([]Point, p.threshold)
+	for i := range commitments {
+		commitments[i] = p.group.Identity()
+	}
+	for _, dealer := range qualified {
+		share.Add(share, p.shares[dealer])
+		for i, commitment := range p.commitments[dealer] {
+			commitments[i] = commitments[i].Add(commitment)
+		}
+	}
+	share.Mod(share, p.group.Order())
+
+	return &Result{
+		Group:       p.group,
+		Index:       p.index,
+		Share:       share,
+		PublicKey:   commitments[0],
+		Commitments: commitments,
+		Qualified:   qualified,
+	}, nil
+}
+
+//	run all rounds of the DKG over the transport
+func Run(transport Transport, participants []*Participant) ([]*Result, error) {
+	for _, p := range participants {
+		deal, shares, err := p.Deal()
+		if err != nil {
+			return nil, err
+		}
+		transport.Broadcast(deal)
+		for _, share := range shares {
+			transport.Send(share.Recipient, share)
+		}
+	}
+
+	inboxes := receiveAll(transport, participants)
+	for i, p := range participants {
+		var deals []*DealMessage
+		var shares []*ShareMessage
+		for _, message := range inboxes[i] {
+			switch m := message.(type) {
+			case *DealMessage:
+				deals = append(deals, m)
+			case *ShareMessage:
+				shares = append(shares, m)
+			}
+		}
+		for _, complaint := range p.ProcessDeals(deals, shares) {
+			transport.Broadcast(complaint)
+		}
+	}
+
+	inboxes = receiveAll(transport, participants)
+	for i, p := range participants {
+		var complaints []*ComplaintMessage
+		for _, message := range inboxes[i] {
+			if m, ok := message.(*ComplaintMessage); ok {
+				complaints = append(complaints, m)
+			}
+		}
+		for _, justification := range p.ProcessComplaints(complaints) {
+			transport.Broadcast(justification)
+		}
+	}
+
+	inboxes = receiveAll(transport, participants)
+	results := make([]*Result, 0, len(participants))
+	for i, p := range participants {
+		var justifications []*JustificationMessage
+		for _, message := range inboxes[i] {
+			if m, ok := message.(*JustificationMessage); ok {
+				justifications = append(justifications, m)
+			}
+		}
+		p.ProcessJustifications(justifications)
+
+		result, err := p.Result()
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+//	drain every inbox before the round is processed, so messages of the next round are not mixed in
+func receiveAll(transport Transport, participants []*Participant) [][]Message {
+	inboxes := make([][]Message, len(participants))
+	for i, p := range participants {
+		inboxes[i] = transport.Receive(p.index)
+	}
+	return inboxes
+}
+
+//	Feldman check: g^share == prod C_k^(index^k)
+func VerifyShare(group Group, commitments []Point, index uint32, share *big.Int) bool {
+	if share == nil {
+		return false
+	}
+	return group.Base().Mul(share).Equal(evalCommitments(group, commitments, index))
+}
+
+//	public key of the share at index
+func (r *Result) PublicShare(index uint32) Point {
+	return evalCommitments(r.Group, r.Commitments, index)
+}
+
+//	key share usable by the bls threshold functions
+func (r *Result) BLSKeyShare() (*bls.KeyShare, error) {
+	if r.Group != BLS {
+		return nil, ErrWrongGroup
+	}
+	priKey := bls.ScalarToKey(r.Share)
+	return &bls.KeyShare{
+		Index:  r.Index,
+		PriKey: priKey,
+		PubKey: g2pubs.PrivToPub(priKey),
+	}, nil
+}
+
+//	group public key for the bls package
+func (r *Result) BLSPublicKey() (*g2pubs.PublicKey, error) {
+	if r.Group != BLS {
+		return nil, ErrWrongGroup
+	}
+	return g2pubs.NewPublicKeyFromG2(r.PublicKey.(blsPoint).p.ToAffine()), nil
+}
+
+//	group public key for the schnorr package
+func (r *Result) SchnorrPublicKey() ([33]byte, error) {
+	var pubKey [33]byte
+	if r.Group != Secp256k1 {
+		return pubKey, ErrWrongGroup
+	}
+	copy(pubKey[:], r.PublicKey.Bytes())
+	return pubKey, nil
+}
+
+//	recover the shared secret from t shares, only for tests and key escrow
+func RecoverSecret(group Group, shares map[uint32]*big.Int) (*big.Int, error) {
+	indices := make([]uint32, 0, len(shares))
+	for index := range shares {
+		indices = append(indices, index)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+
+	secret := new(big.Int)
+	for i, xi := range indices {
+		if xi == 0 {
+			return nil, ErrInvalidIndex
+		}
+		num, den := big.NewInt(1), big.NewInt(1)
+		for j, xj := range indices {
+			if i == j {
+				continue
+			}
+			num.Mul(num, new(big.Int).SetUint64(uint64(xj)))
+			den.Mul(den, new(big.Int).Sub(new(big.Int).SetUint64(uint64(xj)), new(big.Int).SetUint64(uint64(xi))))
+		}
+		den.Mod(den, group.Order())
+		den.ModInverse(den, group.Order())
+		num.Mul(num, den)
+		secret.Add(secret, num.Mul(num, shares[xi]))
+	}
+	return secret.Mod(secret, group.Order()), nil
+}
+
+func (p *Participant) validIndex(index uint32) bool {
+	return index != 0 && int(index) <= p.n
+}
+
+func evalPolynomial(coefficients []*big.Int, x uint32, order *big.Int) *big.Int {
+	bx := new(big.Int).SetUint64(uint64(x))
+	result := new(big.Int)
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		result.Mul(result, bx)
+		result.Add(result, coefficients[i])
+		result.Mod(result, order)
+	}
+	return result
+}
+
+func evalCommitments(group Group, commitments []Point, x uint32) Point {
+	bx := new(big.Int).SetUint64(uint64(x))
+	result := group.Identity()
+	for i := len(commitments) - 1; i >= 0; i-- {
+		result = result.Mul(bx).Add(commitments[i])
+	}
+	return result
+}
+
+func containsIndex(indices []uint32, index uint32) bool {
+	for _, i := range indices {
+		if i == index {
+			return true
+		}
+	}
+	return false
+}
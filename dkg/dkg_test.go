@@ -0,0 +1,245 @@
+package dkg
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"go-cryptology/bls"
+	"go-cryptology/schnorr"
+)
+
+func newParticipants(t *testing.T, group Group, threshold int, n int) []*Participant {
+	participants := make([]*Participant, n)
+	for i := range participants {
+		p, err := NewParticipant(group, uint32(i+1), threshold, n, rand.Reader)
+		if err != nil {
+			t.Fatalf("create participant failed, %v\n", err)
+		}
+		participants[i] = p
+	}
+	return participants
+}
+
+func checkResults(t *testing.T, results []*Result, threshold int) {
+	for _, result := range results {
+		if !result.PublicKey.Equal(results[0].PublicKey) {
+			t.Fatalf("participant %d got a different group public key\n", result.Index)
+		}
+		if !result.Group.Base().Mul(result.Share).Equal(results[0].PublicShare(result.Index)) {
+			t.Fatalf("share of participant %d does not match the public polynomial\n", result.Index)
+		}
+	}
+
+	shares := make(map[uint32]*big.Int)
+	for _, result := range results[len(results)-threshold:] {
+		shares[result.Index] = result.Share
+	}
+	secret, err := RecoverSecret(results[0].Group, shares)
+	if err != nil {
+		t.Fatalf("recover secret failed, %v\n", err)
+	}
+	if !results[0].Group.Base().Mul(secret).Equal(results[0].PublicKey) {
+		t.Fatalf("recovered secret does not match the group public key\n")
+	}
+}
+
+func TestDKGBLS(t *testing.T) {
+	fmt.Println("Test : dkg for bls ...")
+
+	t0 := time.Now()
+
+	participants := newParticipants(t, BLS, 3, 5)
+	results, err := Run(NewMemoryTransport(5), participants)
+	if err != nil {
+		t.Fatalf("run dkg failed, %v\n", err)
+	}
+	checkResults(t, results, 3)
+
+	//	threshold sign with the generated shares
+	message := []byte("hello world")
+	var partials []*bls.PartialSignature
+	for _, result := range results[1:4] {
+		share, err := result.BLSKeyShare()
+		if err != nil {
+			t.Fatalf("convert share failed, %v\n", err)
+		}
+		partial := bls.PartialSign(message, share)
+		if !bls.VerifyPartial(message, share.PubKey, partial) {
+			t.Fatalf("partial signature %d failed to verify\n", share.Index)
+		}
+		partials = append(partials, partial)
+	}
+	signature, err := bls.RecoverSignature(partials, 3)
+	if err != nil {
+		t.Fatalf("recover signature failed, %v\n", err)
+	}
+
+	pubKey, _ := results[0].BLSPublicKey()
+	result := bls.Verify(message, pubKey, signature)
+	wanted := true
+	if result != wanted {
+		t.Fatalf("got result %v but expected %v\n", result, wanted)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func TestDKGSecp256k1(t *testing.T) {
+	fmt.Println("Test : dkg for schnorr ...")
+
+	t0 := time.Now()
+
+	participants := newParticipants(t, Secp256k1, 2, 3)
+	results, err := Run(NewMemoryTransport(3), participants)
+	if err != nil {
+		t.Fatalf("run dkg failed, %v\n", err)
+	}
+	checkResults(t, results, 2)
+
+	//	the recovered group key signs for the group public key
+	secret, _ := RecoverSecret(Secp256k1, map[uint32]*big.Int{1: results[0].Share, 3: results[2].Share})
+	message := sha256.Sum256([]byte("hello world"))
+	signature, err := schnorr.Sign(message, secret)
+	if err != nil {
+		t.Fatalf("sign failed, %v\n", err)
+	}
+
+	pubKey, _ := results[1].SchnorrPublicKey()
+	result, err := schnorr.Verify(message, pubKey, signature)
+	wanted := true
+	if result != wanted {
+		t.Fatalf("got result %v but expected %v, %v\n", result, wanted, err)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+//	dealer 2 sends a bad share to 3 but justifies it correctly
+func TestDKGComplaintJustified(t *testing.T) {
+	fmt.Println("Test : dkg complaint answered by dealer ...")
+
+	results, qualified := runWithBadShare(t, false)
+	if len(qualified) != 4 {
+		t.Fatalf("got qualified %v but expected all dealers\n", qualified)
+	}
+	checkResults(t, results, 3)
+}
+
+//	dealer 2 sends a bad share to 3 and reveals a bad share again
+func TestDKGComplaintDisqualifies(t *testing.T) {
+	fmt.Println("Test : dkg disqualifies cheating dealer ...")
+
+	results, qualified := runWithBadShare(t, true)
+	for _, index := range qualified {
+		if index == 2 {
+			t.Fatalf("cheating dealer 2 is still qualified\n")
+		}
+	}
+	checkResults(t, results, 3)
+}
+
+func runWithBadShare(t *testing.T, cheatJustification bool) ([]*Result, []uint32) {
+	const n = 4
+	participants := newParticipants(t, Secp256k1, 3, n)
+	transport := NewMemoryTransport(n)
+
+	for _, p := range participants {
+		deal, shares, _ := p.Deal()
+		transport.Broadcast(deal)
+		for _, share := range shares {
+			if share.Dealer == 2 && share.Recipient == 3 {
+				share.Share = new(big.Int).Add(share.Share, big.NewInt(1))
+			}
+			transport.Send(share.Recipient, share)
+		}
+	}
+
+	inboxes := receiveAll(transport, participants)
+	for i, p := range participants {
+		var deals []*DealMessage
+		var shares []*ShareMessage
+		for _, message := range inboxes[i] {
+			switch m := message.(type) {
+			case *DealMessage:
+				deals = append(deals, m)
+			case *ShareMessage:
+				shares = append(shares, m)
+			}
+		}
+		for _, complaint := range p.ProcessDeals(deals, shares) {
+			if complaint.Complainer != 3 || complaint.Dealer != 2 {
+				t.Fatalf("unexpected complaint %+v\n", complaint)
+			}
+			transport.Broadcast(complaint)
+		}
+	}
+
+	inboxes = receiveAll(transport, participants)
+	for i, p := range participants {
+		var complaints []*ComplaintMessage
+		for _, message := range inboxes[i] {
+			complaints = append(complaints, message.(*ComplaintMessage))
+		}
+		for _, justification := range p.ProcessComplaints(complaints) {
+			if cheatJustification {
+				justification.Share = new(big.Int).Add(justification.Share, big.NewInt(1))
+			}
+			transport.Broadcast(justification)
+		}
+	}
+
+	inboxes = receiveAll(transport, participants)
+	var results []*Result
+	for i, p := range participants {
+		var justifications []*JustificationMessage
+		for _, message := range inboxes[i] {
+			justifications = append(justifications, message.(*JustificationMessage))
+		}
+		p.ProcessJustifications(justifications)
+
+		result, err := p.Result()
+		if err != nil {
+			t.Fatalf("dkg result failed, %v\n", err)
+		}
+		results = append(results, result)
+	}
+	return results, results[0].Qualified
+}
+
+func TestMemoryTransportOrder(t *testing.T) {
+	fmt.Println("Test : memory transport delivers by sender ...")
+
+	transport := NewMemoryTransport(3)
+	transport.Broadcast(&ComplaintMessage{Complainer: 3, Dealer: 1})
+	transport.Send(2, &ShareMessage{Dealer: 1, Recipient: 2})
+	transport.Broadcast(&ComplaintMessage{Complainer: 2, Dealer: 1})
+
+	messages := transport.Receive(2)
+	if len(messages) != 3 {
+		t.Fatalf("got %d messages but expected 3\n", len(messages))
+	}
+	for i, wanted := range []uint32{1, 2, 3} {
+		if messages[i].Sender() != wanted {
+			t.Fatalf("message %d from %d but expected %d\n", i, messages[i].Sender(), wanted)
+		}
+	}
+	if len(transport.Receive(2)) != 0 {
+		t.Fatalf("queue was not drained\n")
+	}
+}
+
+func BenchmarkDKGSecp256k1(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		participants := make([]*Participant, 5)
+		for j := range participants {
+			participants[j], _ = NewParticipant(Secp256k1, uint32(j+1), 3, 5, rand.Reader)
+		}
+		if _, err := Run(NewMemoryTransport(5), participants); err != nil {
+			b.Fatalf("run dkg failed, %v\n", err)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package dkg
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+	bls12 "github.com/phoreproject/bls"
+)
+
+//	element of the group the shared key lives in
+type Point interface {
+	Add(other Point) Point
+	Mul(k *big.Int) Point
+	Equal(other Point) bool
+	Bytes() []byte
+}
+
+//	prime order group with a fixed generator
+type Group interface {
+	Order() *big.Int
+	Base() Point
+	Identity() Point
+}
+
+var (
+	//	G2 of BLS12-381, matches the public keys of the bls package
+	BLS Group = blsGroup{}
+	//	secp256k1, matches the public keys of the schnorr package
+	Secp256k1 Group = secpGroup{}
+)
+
+type blsGroup struct{}
+
+type blsPoint struct {
+	p *bls12.G2Projective
+}
+
+func (blsGroup) Order() *big.Int {
+	return bls12.RFieldModulus.ToBig()
+}
+
+func (blsGroup) Base() Point {
+	return blsPoint{bls12.G2ProjectiveOne.Copy()}
+}
+
+func (blsGroup) Identity() Point {
+	return blsPoint{bls12.G2ProjectiveZero.Copy()}
+}
+
+func (p blsPoint) Add(other Point) Point {
+	return blsPoint{p.p.Add(other.(blsPoint).p)}
+}
+
+func (p blsPoint) Mul(k *big.Int) Point {
+	repr, err := bls12.FRReprFromBigInt(new(big.Int).Mod(k, BLS.Order()))
+	if err != nil {
+		panic(err)
+	}
+	return blsPoint{p.p.MulFR(repr)}
+}
+
+func (p blsPoint) Equal(other Point) bool {
+	o, ok := other.(blsPoint)
+	return ok && p.p.Equals(o.p)
+}
+
+func (p blsPoint) Bytes() []byte {
+	b := bls12.CompressG2(p.p.ToAffine())
+	return b[:]
+}
+
+type secpGroup struct{}
+
+type secpPoint struct {
+	x, y *big.Int
+}
+
+func (secpGroup) Order() *big.Int {
+	return btcec.S256().N
+}
+
+func (secpGroup) Base() Point {
+	params := btcec.S256().Params()
+	return secpPoint{params.Gx, params.Gy}
+}
+
+func (secpGroup) Identity() Point {
+	return secpPoint{new(big.Int), new(big.Int)}
+}
+
+func (p secpPoint) isIdentity() bool {
+	return p.x.Sign() == 0 && p.y.Sign() == 0
+}
+
+func (p secpPoint) Add(other Point) Point {
+	o := other.(secpPoint)
+	if p.isIdentity() {
+		return o
+	}
+	if o.isIdentity() {
+		return p
+	}
+	x, y := btcec.S256().Add(p.x, p.y, o.x, o.y)
+	return secpPoint{x, y}
+}
+
+func (p secpPoint) Mul(k *big.Int) Point {
+	k = new(big.Int).Mod(k, Secp256k1.Order())
+	if k.Sign() == 0 || p.isIdentity() {
+		return Secp256k1.Identity()
+	}
+	x, y := btcec.S256().ScalarMult(p.x, p.y, k.Bytes())
+	return secpPoint{x, y}
+}
+
+func (p secpPoint) Equal(other Point) bool {
+	o, ok := other.(secpPoint)
+	return ok && bytes.Equal(p.Bytes(), o.Bytes())
+}
+
+//	compressed SEC1 encoding, identity is encoded as 33 zero bytes
+func (p secpPoint) Bytes() []byte {
+	if p.isIdentity() {
+		return make([]byte, 33)
+	}
+	return (&btcec.PublicKey{Curve: btcec.S256(), X: p.x, Y: p.y}).SerializeCompressed()
+}
@@ -0,0 +1,50 @@
+package dkg
+
+import "math/big"
+
+//	message exchanged between participants
+type Message interface {
+	Sender() uint32
+}
+
+//	round 1, broadcast: commitments to the coefficients of the dealer's polynomial
+type DealMessage struct {
+	Dealer      uint32
+	Commitments []Point
+}
+
+//	round 1, private: share of the dealer's polynomial for one recipient
+type ShareMessage struct {
+	Dealer    uint32
+	Recipient uint32
+	Share     *big.Int
+}
+
+//	round 2, broadcast: complainer received an invalid or no share from dealer
+type ComplaintMessage struct {
+	Complainer uint32
+	Dealer     uint32
+}
+
+//	round 3, broadcast: dealer reveals the share of a complainer
+type JustificationMessage struct {
+	Dealer     uint32
+	Complainer uint32
+	Share      *big.Int
+}
+
+func (m *DealMessage) Sender() uint32 {
+	return m.Dealer
+}
+
+func (m *ShareMessage) Sender() uint32 {
+	return m.Dealer
+}
+
+func (m *ComplaintMessage) Sender() uint32 {
+	return m.Complainer
+}
+
+func (m *JustificationMessage) Sender() uint32 {
+	return m.Dealer
+}
@@ -0,0 +1,46 @@
+package dkg
+
+import "sort"
+
+//	delivers messages between participants
+type Transport interface {
+	Broadcast(message Message)
+	Send(to uint32, message Message)
+	Receive(to uint32) []Message
+}
+
+//	in-memory transport, messages are delivered in a deterministic order
+type MemoryTransport struct {
+	n      int
+	queues map[uint32][]Message
+}
+
+//	create in-memory transport for participants 1..n
+func NewMemoryTransport(n int) *MemoryTransport {
+	return &MemoryTransport{
+		n:      n,
+		queues: make(map[uint32][]Message, n),
+	}
+}
+
+//	deliver message to every participant, including the sender
+func (t *MemoryTransport) Broadcast(message Message) {
+	for i := 1; i <= t.n; i++ {
+		t.Send(uint32(i), message)
+	}
+}
+
+//	deliver message to a single participant
+func (t *MemoryTransport) Send(to uint32, message Message) {
+	t.queues[to] = append(t.queues[to], message)
+}
+
+//	drain pending messages of a participant, ordered by sender
+func (t *MemoryTransport) Receive(to uint32) []Message {
+	messages := t.queues[to]
+	delete(t.queues, to)
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].Sender() < messages[j].Sender()
+	})
+	return messages
+}
@@ -0,0 +1,116 @@
+package ed25519
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"errors"
+	"io"
+	"log"
+
+	"filippo.io/edwards25519"
+)
+
+var (
+	ErrBatchLength = errors.New("[ED25519] number of messages, public keys and signatures differ")
+	ErrBatchFailed = errors.New("[ED25519] batch contains invalid signatures")
+)
+
+//	verify many signatures with one multiscalar multiplication: with random z_i of 128 bits,
+//	8 * ((sum z_i*S_i)B - sum z_i*R_i - sum (z_i*k_i)A_i) == 0.
+//	the rules are those of VerifyZIP215, so that a signature is accepted in a batch
+//	if and only if it is accepted alone.
+//	results[i] tells if signature i is valid, when the batch fails every signature is
+//	checked alone and err is ErrBatchFailed
+func BatchVerify(messages [][]byte, pubKeys []*[32]byte, signatures []*[64]byte) (results []bool, err error) {
+	if len(messages) != len(pubKeys) || len(messages) != len(signatures) {
+		return nil, ErrBatchLength
+	}
+
+	results = make([]bool, len(signatures))
+	failed := false
+	var batch []*batchEntry
+	for i := range signatures {
+		entry := newBatchEntry(messages[i], pubKeys[i], signatures[i])
+		if entry == nil {
+			failed = true
+			continue
+		}
+		entry.index = i
+		results[i] = true
+		batch = append(batch, entry)
+	}
+
+	if len(batch) > 0 && !checkBatch(batch) {
+		for _, entry := range batch {
+			if !checkBatch([]*batchEntry{entry}) {
+				results[entry.index] = false
+				failed = true
+			}
+		}
+	}
+
+	if failed {
+		return results, ErrBatchFailed
+	}
+	return results, nil
+}
+
+type batchEntry struct {
+	index int
+	A, R  *edwards25519.Point
+	S, k  *edwards25519.Scalar
+}
+
+//	decode one signature, nil if S is not canonical or a point is not on the curve
+func newBatchEntry(message []byte, pubKey *[32]byte, signature *[64]byte) *batchEntry {
+	var err error
+	var ok bool
+	entry := new(batchEntry)
+	if entry.S, err = new(edwards25519.Scalar).SetCanonicalBytes(signature[32:]); err != nil {
+		return nil
+	}
+	if entry.A, ok = decodePoint(pubKey[:], false); !ok {
+		return nil
+	}
+	if entry.R, ok = decodePoint(signature[:32], false); !ok {
+		return nil
+	}
+
+	// k = H(R || A || M)
+	h := sha512.New()
+	h.Write(signature[:32])
+	h.Write(pubKey[:])
+	h.Write(message)
+	entry.k, _ = new(edwards25519.Scalar).SetUniformBytes(h.Sum(nil))
+	return entry
+}
+
+//	one multiscalar multiplication over all entries
+func checkBatch(batch []*batchEntry) bool {
+	scalars := make([]*edwards25519.Scalar, 0, 2*len(batch)+1)
+	points := make([]*edwards25519.Point, 0, 2*len(batch)+1)
+
+	sSum := edwards25519.NewScalar()
+	for _, entry := range batch {
+		z := randomScalar()
+		sSum.MultiplyAdd(z, entry.S, sSum)
+
+		zk := new(edwards25519.Scalar).Multiply(z, entry.k)
+		scalars = append(scalars, z.Negate(z), zk.Negate(zk))
+		points = append(points, entry.R, entry.A)
+	}
+	scalars = append(scalars, sSum)
+	points = append(points, edwards25519.NewGeneratorPoint())
+
+	return isSmallOrder(new(edwards25519.Point).VarTimeMultiScalarMult(scalars, points))
+}
+
+//	uniform scalar of 128 bits
+func randomScalar() *edwards25519.Scalar {
+	var b [32]byte
+	if _, err := io.ReadFull(rand.Reader, b[:16]); err != nil {
+		log.Fatal(err)
+	}
+	s, _ := new(edwards25519.Scalar).SetCanonicalBytes(b[:])
+	return s
+}
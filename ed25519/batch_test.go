@@ -0,0 +1,131 @@
+package ed25519
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func genBatch(n int) (messages [][]byte, pubKeys []*[32]byte, signatures []*[64]byte) {
+	for i := 0; i < n; i++ {
+		priKey, pubKey := GenerateKey(rand.Reader)
+		message := []byte(fmt.Sprintf("transaction %d", i))
+		messages = append(messages, message)
+		pubKeys = append(pubKeys, pubKey)
+		signatures = append(signatures, Sign(message, priKey))
+	}
+	return
+}
+
+func TestBatchVerify(t *testing.T) {
+	fmt.Println("Test : ed25519 batch verify ...")
+
+	t0 := time.Now()
+
+	messages, pubKeys, signatures := genBatch(64)
+	results, err := BatchVerify(messages, pubKeys, signatures)
+	if err != nil {
+		t.Fatalf("got result %v but expected %v\n", err, nil)
+	}
+	for i, result := range results {
+		if !result {
+			t.Fatalf("index %d: got result %v but expected %v\n", i, result, true)
+		}
+	}
+
+	//	a signature of another message and a non-canonical S are reported at their index
+	signatures[3] = signatures[4]
+	overflow := *signatures[10]
+	overflow[63] |= 0xf0
+	signatures[10] = &overflow
+	results, err = BatchVerify(messages, pubKeys, signatures)
+	if err != ErrBatchFailed {
+		t.Fatalf("got result %v but expected %v\n", err, ErrBatchFailed)
+	}
+	for i, result := range results {
+		if result != (i != 3 && i != 10) {
+			t.Fatalf("index %d: got result %v but expected %v\n", i, result, !result)
+		}
+	}
+
+	if _, err := BatchVerify(messages[1:], pubKeys, signatures); err != ErrBatchLength {
+		t.Fatalf("got result %v but expected %v\n", err, ErrBatchLength)
+	}
+	if results, err := BatchVerify(nil, nil, nil); err != nil || len(results) != 0 {
+		t.Fatalf("got result %v but expected %v\n", err, nil)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func TestBatchVerifySpeccheck(t *testing.T) {
+	fmt.Println("Test : ed25519 batch verify agrees with VerifyZIP215 ...")
+
+	t0 := time.Now()
+
+	//	the speccheck cases together with honest signatures, accepted as by VerifyZIP215
+	messages, pubKeys, signatures := genBatch(4)
+	for _, c := range speccheckCases {
+		message, _ := hex.DecodeString(c.message)
+		pubKey, signature := new([32]byte), new([64]byte)
+		hex.Decode(pubKey[:], []byte(c.pubKey))
+		hex.Decode(signature[:], []byte(c.signature))
+		messages = append(messages, message)
+		pubKeys = append(pubKeys, pubKey)
+		signatures = append(signatures, signature)
+	}
+
+	results, _ := BatchVerify(messages, pubKeys, signatures)
+	for i := range signatures {
+		wanted := VerifyWithMode(messages[i], pubKeys[i], signatures[i], VerifyZIP215)
+		if results[i] != wanted {
+			t.Fatalf("index %d: got result %v but expected %v\n", i, results[i], wanted)
+		}
+	}
+
+	//	small order keys and commitments pass in a batch
+	var small [][]byte
+	var smallKeys []*[32]byte
+	var smallSignatures []*[64]byte
+	for _, a := range smallOrderEncodings {
+		pubKey, signature := new([32]byte), new([64]byte)
+		hex.Decode(pubKey[:], []byte(a))
+		hex.Decode(signature[:32], []byte(a))
+		small = append(small, []byte("Zcash"))
+		smallKeys = append(smallKeys, pubKey)
+		smallSignatures = append(smallSignatures, signature)
+	}
+	if _, err := BatchVerify(small, smallKeys, smallSignatures); err != nil {
+		t.Fatalf("got result %v but expected %v\n", err, nil)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+var batchSizes = []int{8, 64, 512, 4096}
+
+func BenchmarkBatchVerify(b *testing.B) {
+	for _, size := range batchSizes {
+		messages, pubKeys, signatures := genBatch(size)
+		b.Run(fmt.Sprint(size), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				BatchVerify(messages, pubKeys, signatures)
+			}
+		})
+	}
+}
+
+func BenchmarkVerifyBatchSizes(b *testing.B) {
+	for _, size := range batchSizes {
+		messages, pubKeys, signatures := genBatch(size)
+		b.Run(fmt.Sprint(size), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				for i := range signatures {
+					Verify(messages[i], pubKeys[i], signatures[i])
+				}
+			}
+		})
+	}
+}
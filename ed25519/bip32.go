@@ -0,0 +1,205 @@
+package ed25519
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+
+	"filippo.io/edwards25519"
+	coname "github.com/yahoo/coname/ed25519/edwards25519"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+var (
+	ErrInvalidMasterKey = errors.New("[ED25519] third highest bit of the master secret is set, use another seed")
+	ErrHardenedPublic   = errors.New("[ED25519] hardened keys cannot be derived from a public key")
+	ErrInvalidChild     = errors.New("[ED25519] secret of the child key is not below 2^255 or is 0 mod L, use another index")
+)
+
+//	BIP32-Ed25519 extended private key, KL is the secret scalar and KR the prefix of the nonces,
+//	the keys are not seeds and sign with Sign of the key instead of the package Sign
+type ExtendedKey struct {
+	KL, KR    [32]byte
+	ChainCode [32]byte
+}
+
+//	BIP32-Ed25519 extended public key
+type ExtendedPublicKey struct {
+	Key       [32]byte
+	ChainCode [32]byte
+}
+
+//	BIP32-Ed25519 master key, (kL, kR) = SHA512(seed) and c = SHA256(0x01 || seed).
+//	seeds for which the third highest bit of kL is set are rejected
+func NewExtendedMasterKey(seed []byte) (*ExtendedKey, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, ErrSeedSize
+	}
+	digest := Hash(seed)
+	if digest[31]&32 != 0 {
+		return nil, ErrInvalidMasterKey
+	}
+	digest[0] &= 248
+	digest[31] &= 127
+	digest[31] |= 64
+
+	k := new(ExtendedKey)
+	copy(k.KL[:], digest[:32])
+	copy(k.KR[:], digest[32:])
+	k.ChainCode = sha256.Sum256(append([]byte{1}, seed...))
+	return k, nil
+}
+
+//	Icarus master key of Cardano (CIP-3), PBKDF2-HMAC-SHA512 of 4096 rounds with the passphrase
+//	as password and the BIP-39 entropy as salt gives kL || kR || c, the third highest bit of kL
+//	is cleared instead of rejected
+func NewIcarusMasterKey(entropy []byte, passphrase string) (*ExtendedKey, error) {
+	if len(entropy) < 16 || len(entropy) > 64 {
+		return nil, ErrSeedSize
+	}
+	digest := pbkdf2.Key([]byte(passphrase), entropy, 4096, 96, sha512.New)
+	digest[0] &= 248
+	digest[31] &= 31
+	digest[31] |= 64
+
+	k := new(ExtendedKey)
+	copy(k.KL[:], digest[:32])
+	copy(k.KR[:], digest[32:64])
+	copy(k.ChainCode[:], digest[64:])
+	return k, nil
+}
+
+//	child at index, hardened from 2^31:
+//	Z = HMAC-SHA512(c, 0x00 || kL || kR || index) or HMAC-SHA512(c, 0x02 || A || index),
+//	kL_i = 8 * Z[:28] + kL, kR_i = Z[32:] + kR mod 2^256.
+//	the chain code is the right half of the same HMAC with prefix 0x01 or 0x03.
+//	a child with kL_i >= 2^255 or kL_i = 0 mod L is rejected
+func (k *ExtendedKey) Child(index uint32) (*ExtendedKey, error) {
+	var data []byte
+	if index >= HardenedOffset {
+		data = append(append([]byte{0}, k.KL[:]...), k.KR[:]...)
+	} else {
+		data = append([]byte{2}, k.Public().Key[:]...)
+	}
+	Z, chainCode := childMACs(k.ChainCode[:], data, index)
+
+	child := new(ExtendedKey)
+	zL := multiplyByCofactor(Z[:28])
+	if addBytes(&child.KL, &k.KL, &zL) != 0 || !isValidChildScalar(&child.KL) {
+		return nil, ErrInvalidChild
+	}
+	var zR [32]byte
+	copy(zR[:], Z[32:])
+	addBytes(&child.KR, &k.KR, &zR)
+	child.ChainCode = chainCode
+	return child, nil
+}
+
+//	extended public key, A = [kL]B
+func (k *ExtendedKey) Public() *ExtendedPublicKey {
+	var A coname.ExtendedGroupElement
+	coname.GeScalarMultBase(&A, &k.KL)
+
+	p := new(ExtendedPublicKey)
+	A.ToBytes(&p.Key)
+	p.ChainCode = k.ChainCode
+	return p
+}
+
+//	digital signature with the extended key, verified by Verify with the public key
+func (k *ExtendedKey) Sign(message []byte) *[64]byte {
+	return signExpanded(nil, message, &k.KL, &k.KR, k.Public().Key[:])
+}
+
+//	non-hardened child, A_i = A + [8 * Z[:28]]B, the same key as the public key of the private child
+func (p *ExtendedPublicKey) Child(index uint32) (*ExtendedPublicKey, error) {
+	if index >= HardenedOffset {
+		return nil, ErrHardenedPublic
+	}
+	A, err := new(edwards25519.Point).SetBytes(p.Key[:])
+	if err != nil {
+		return nil, ErrInvalidPublicKey
+	}
+	Z, chainCode := childMACs(p.ChainCode[:], append([]byte{2}, p.Key[:]...), index)
+
+	zL := multiplyByCofactor(Z[:28])
+	s, _ := new(edwards25519.Scalar).SetCanonicalBytes(zL[:])
+	A.Add(A, new(edwards25519.Point).ScalarBaseMult(s))
+
+	child := new(ExtendedPublicKey)
+	copy(child.Key[:], A.Bytes())
+	child.ChainCode = chainCode
+	return child, nil
+}
+
+//	derive BIP32-Ed25519 key from seed along a path such as m/1852'/1815'/0'/0/0
+func DeriveExtendedKey(seed []byte, path string) (*ExtendedKey, error) {
+	indices, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := NewExtendedMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	for _, index := range indices {
+		if key, err = key.Child(index); err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+//	Z and the chain code, data is prefixed by 0x00 or 0x02 which becomes 0x01 or 0x03 for the chain code
+func childMACs(chainCode []byte, data []byte, index uint32) (Z []byte, child [32]byte) {
+	var i [4]byte
+	binary.LittleEndian.PutUint32(i[:], index)
+
+	mac := hmac.New(sha512.New, chainCode)
+	mac.Write(data)
+	mac.Write(i[:])
+	Z = mac.Sum(nil)
+
+	mac.Reset()
+	mac.Write([]byte{data[0] + 1})
+	mac.Write(data[1:])
+	mac.Write(i[:])
+	copy(child[:], mac.Sum(nil)[32:])
+	return Z, child
+}
+
+//	8 * z for z of 28 bytes, little-endian
+func multiplyByCofactor(z []byte) [32]byte {
+	var out [32]byte
+	carry := byte(0)
+	for i := range z {
+		out[i] = z[i]<<3 | carry
+		carry = z[i] >> 5
+	}
+	out[len(z)] = carry
+	return out
+}
+
+//	out = a + b mod 2^256, little-endian, returns the carry out of 2^256
+func addBytes(out, a, b *[32]byte) uint16 {
+	carry := uint16(0)
+	for i := range out {
+		sum := uint16(a[i]) + uint16(b[i]) + carry
+		out[i], carry = byte(sum), sum>>8
+	}
+	return carry
+}
+
+//	kL below 2^255 and not 0 mod L, otherwise [kL]B is not a usable public key
+func isValidChildScalar(kL *[32]byte) bool {
+	if kL[31]&128 != 0 {
+		return false
+	}
+	var wide [64]byte
+	copy(wide[:], kL[:])
+	s, _ := new(edwards25519.Scalar).SetUniformBytes(wide[:])
+	return s.Equal(edwards25519.NewScalar()) == 0
+}
@@ -0,0 +1,197 @@
+package ed25519
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+)
+
+//	CIP-3 Icarus master keys kL || kR || c, the entropy is the one of the mnemonic
+//	"eight country switch draw meat scout mystery blade tip drift useless good keep usage title"
+var icarusVectors = []struct {
+	entropy, passphrase, key string
+}{
+	{"46e62370a138a182a498b8e2885bc032379ddf38", "", "c065afd2832cd8b087c4d9ab7011f481ee1e0721e78ea5dd609f3ab3f156d245d176bd8fd4ec60b4731c3918a2a72a0226c0cd119ec35b47e4d55884667f552a23f7fdcd4a10c6cd2c7393ac61d877873e248f417634aa3d812af327ffe9d620"},
+	{"46e62370a138a182a498b8e2885bc032379ddf38", "foo", "70531039904019351e1afb361cd1b312a4d0565d4ff9f8062d38acf4b15cce41d7b5738d9c893feea55512a3004acb0d222c35d3e3d5cde943a15a9824cbac59443cf67e589614076ba01e354b1a432e0e6db3b59e37fc56b5fb0222970a010e"},
+}
+
+//	vectors of the Rust ed25519-bip32 crate: the key D1, its child D1_H0 at 0x80000000
+//	and the signature of "Hello World" with D1_H0
+var bip32Vector = struct {
+	key, hardenedChild, signature string
+}{
+	"f8a29231ee38d6c5bf715d5bac21c750577aa3798b22d79d65bf97d6fadea15adcd1ee1abdf78bd4be64731a12deb94d3671784112eb6f364b871851fd1c9a247384db9ad6003bbd08b3b1ddc0d07a597293ff85e961bf252b331262eddfad0d",
+	"60d399da83ef80d8d4f8d223239efdc2b8fef387e1b5219137ffb4e8fbdea15adc9366b7d003af37c11396de9a83734e30e05e851efa32745c9cd7b42712c890608763770eddf77248ab652984b21b849760d1da74a6f5bd633ce41adceef07a",
+	"90194d57cde4fdadd01eb7cf161780c277e129fc7135b97779a3268837e4cd2e9444b9bb91c0e84d23bba870df3c4bda91a110ef735638fa7a34ea2046d4be04",
+}
+
+func extendedKeyFromHex(key string) *ExtendedKey {
+	b, _ := hex.DecodeString(key)
+	k := new(ExtendedKey)
+	copy(k.KL[:], b[:32])
+	copy(k.KR[:], b[32:64])
+	copy(k.ChainCode[:], b[64:])
+	return k
+}
+
+func extendedKeyHex(k *ExtendedKey) string {
+	return hex.EncodeToString(k.KL[:]) + hex.EncodeToString(k.KR[:]) + hex.EncodeToString(k.ChainCode[:])
+}
+
+func TestBIP32Ed25519Vectors(t *testing.T) {
+	fmt.Println("Test : BIP32-Ed25519 vectors ...")
+
+	t0 := time.Now()
+
+	for _, v := range icarusVectors {
+		entropy, _ := hex.DecodeString(v.entropy)
+		master, err := NewIcarusMasterKey(entropy, v.passphrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if extendedKeyHex(master) != v.key {
+			t.Fatalf("got result %v but expected %v\n", extendedKeyHex(master), v.key)
+		}
+	}
+
+	//	hardened child, chain code and signature with the child
+	key := extendedKeyFromHex(bip32Vector.key)
+	child, err := key.Child(HardenedOffset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if extendedKeyHex(child) != bip32Vector.hardenedChild {
+		t.Fatalf("got result %v but expected %v\n", extendedKeyHex(child), bip32Vector.hardenedChild)
+	}
+	signature := child.Sign([]byte("Hello World"))
+	if hex.EncodeToString(signature[:]) != bip32Vector.signature {
+		t.Fatalf("got result %x but expected %v\n", signature[:], bip32Vector.signature)
+	}
+	if !Verify([]byte("Hello World"), &child.Public().Key, signature) {
+		t.Fatalf("got result %v but expected %v\n", false, true)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func TestBIP32Ed25519(t *testing.T) {
+	fmt.Println("Test : BIP32-Ed25519 derivation ...")
+
+	t0 := time.Now()
+
+	entropy, _ := hex.DecodeString(icarusVectors[0].entropy)
+	master, err := NewIcarusMasterKey(entropy, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//	public derivation agrees with private derivation
+	account := master
+	for _, index := range []uint32{1852 + HardenedOffset, 1815 + HardenedOffset, HardenedOffset} {
+		if account, err = account.Child(index); err != nil {
+			t.Fatal(err)
+		}
+	}
+	accountPub := account.Public()
+	change, err := account.Child(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, index := range []uint32{0, 1, 42, HardenedOffset - 1} {
+		child, err := change.Child(index)
+		if err != nil {
+			t.Fatal(err)
+		}
+		changePub, err := accountPub.Child(0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		childPub, err := changePub.Child(index)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if *child.Public() != *childPub {
+			t.Fatalf("got result %x but expected %x\n", childPub.Key, child.Public().Key)
+		}
+
+		//	the secret scalar stays clamped and signatures verify with the public key
+		if child.KL[0]&7 != 0 || child.KL[31]&128 != 0 {
+			t.Fatalf("got result %x but expected a multiple of 8 below 2^255\n", child.KL)
+		}
+		message := []byte("transaction")
+		signature := child.Sign(message)
+		if !Verify(message, &childPub.Key, signature) || !VerifyWithMode(message, &childPub.Key, signature, VerifyStrict) {
+			t.Fatalf("got result %v but expected %v\n", false, true)
+		}
+	}
+
+	//	hardened children differ from normal ones and have no public derivation
+	hardened, err := account.Child(HardenedOffset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *hardened == *change {
+		t.Fatalf("got result %x but expected a different key\n", hardened.KL)
+	}
+	if _, err := accountPub.Child(HardenedOffset); err != ErrHardenedPublic {
+		t.Fatalf("got result %v but expected %v\n", err, ErrHardenedPublic)
+	}
+
+	//	the master key of the paper rejects this seed and accepts it with the passphrase "foo"
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	if _, err := NewExtendedMasterKey(MnemonicToSeed(mnemonic, "")); err != ErrInvalidMasterKey {
+		t.Fatalf("got result %v but expected %v\n", err, ErrInvalidMasterKey)
+	}
+	seed := MnemonicToSeed(mnemonic, "foo")
+	key, err := NewExtendedMasterKey(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, index := range []uint32{1852 + HardenedOffset, 1815 + HardenedOffset, HardenedOffset, 0, 0} {
+		if key, err = key.Child(index); err != nil {
+			t.Fatal(err)
+		}
+	}
+	derived, err := DeriveExtendedKey(seed, "m/1852'/1815'/0'/0/0")
+	if err != nil || *derived != *key {
+		t.Fatalf("got result %v but expected %v\n", derived, key)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func TestBIP32Ed25519InvalidChild(t *testing.T) {
+	fmt.Println("Test : BIP32-Ed25519 rejects invalid children ...")
+
+	t0 := time.Now()
+
+	//	2^255 - 8 reaches 2^255 with any non-zero 8 * Z[:28]
+	key := extendedKeyFromHex(bip32Vector.key)
+	for i := range key.KL {
+		key.KL[i] = 255
+	}
+	key.KL[0] = 248
+	key.KL[31] = 127
+	for _, index := range []uint32{0, HardenedOffset} {
+		if _, err := key.Child(index); err != ErrInvalidChild {
+			t.Fatalf("got result %v but expected %v\n", err, ErrInvalidChild)
+		}
+	}
+
+	//	0, L and 2L are 0 mod L
+	L := [32]byte{0xed, 0xd3, 0xf5, 0x5c, 0x1a, 0x63, 0x12, 0x58, 0xd6, 0x9c, 0xf7, 0xa2, 0xde, 0xf9, 0xde, 0x14, 31: 0x10}
+	var twoL [32]byte
+	addBytes(&twoL, &L, &L)
+	for _, kL := range []*[32]byte{new([32]byte), &L, &twoL} {
+		if isValidChildScalar(kL) {
+			t.Fatalf("got result %v but expected %v\n", true, false)
+		}
+	}
+	L[0]++
+	if !isValidChildScalar(&L) {
+		t.Fatalf("got result %v but expected %v\n", false, true)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
@@ -0,0 +1,17 @@
+package ed25519
+
+import (
+	"crypto/sha512"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+//	BIP-39 seed of a mnemonic sentence, PBKDF2-HMAC-SHA512 of 2048 rounds
+//	with the salt "mnemonic" || passphrase, both strings in NFKD.
+//	the words and their checksum are not checked
+func MnemonicToSeed(mnemonic string, passphrase string) []byte {
+	password := norm.NFKD.String(mnemonic)
+	salt := "mnemonic" + norm.NFKD.String(passphrase)
+	return pbkdf2.Key([]byte(password), []byte(salt), 2048, 64, sha512.New)
+}
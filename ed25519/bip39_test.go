@@ -0,0 +1,36 @@
+package ed25519
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestMnemonicToSeed(t *testing.T) {
+	fmt.Println("Test : BIP-39 mnemonic to seed ...")
+
+	t0 := time.Now()
+
+	//	BIP-39 test vectors, the passphrase is TREZOR
+	vectors := [][2]string{
+		{"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04"},
+		{"legal winner thank year wave sausage worth useful legal winner thank yellow", "2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607"},
+		{"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo wrong", "ac27495480225222079d7be181583751e86f571027b0497b5b5d11218e0a8a13332572917f0f8e5a589620c6f15b11c61dee327651a14c34e18231052e48c069"},
+		{"letter advice cage absurd amount doctor acoustic avoid letter advice cage absurd amount doctor acoustic avoid letter always", "107d7c02a5aa6f38c58083ff74f04c607c2d2c0ecc55501dadd72d025b751bc27fe913ffb796f841c49b1d33b610cf0e91d3aa239027f5e99fe4ce9e5088cd65"},
+	}
+	for _, v := range vectors {
+		seed := MnemonicToSeed(v[0], "TREZOR")
+		if hex.EncodeToString(seed) != v[1] {
+			t.Fatalf("got result %x but expected %v\n", seed, v[1])
+		}
+	}
+
+	//	composed and decomposed passphrases give the same seed
+	if !bytes.Equal(MnemonicToSeed(vectors[0][0], "caf\u00e9"), MnemonicToSeed(vectors[0][0], "cafe\u0301")) {
+		t.Fatalf("got result %v but expected %v\n", false, true)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
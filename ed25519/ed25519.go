@@ -37,20 +37,31 @@ func GenerateKey(rand io.Reader) (priKey *[64]byte, pubKey *[32]byte) {
 
 //	digital signature
 func Sign(message []byte, priKey *[64]byte) *[64]byte {
-	h := sha512.New()
-	h.Write(priKey[:32])
+	return sign(nil, message, priKey)
+}
 
-	var digest1, messageDigest, hramDigest [64]byte
-	var expandedSecretKey [32]byte
-	h.Sum(digest1[:0])
-	copy(expandedSecretKey[:], digest1[:])
+//	signature with dom prefixed to both hashes, dom is empty for PureEdDSA
+func sign(dom []byte, message []byte, priKey *[64]byte) *[64]byte {
+	digest := Hash(priKey[:32])
+
+	var expandedSecretKey, prefix [32]byte
+	copy(expandedSecretKey[:], digest)
 	expandedSecretKey[0] &= 248
 	expandedSecretKey[31] &= 63
 	expandedSecretKey[31] |= 64
+	copy(prefix[:], digest[32:])
 
-	h.Reset()
-	h.Write(digest1[32:])
+	return signExpanded(dom, message, &expandedSecretKey, &prefix, priKey[32:])
+}
+
+//	signature with the secret scalar, the prefix of the nonce and the public key
+func signExpanded(dom []byte, message []byte, expandedSecretKey *[32]byte, prefix *[32]byte, pubKey []byte) *[64]byte {
+	h := sha512.New()
+	h.Write(dom)
+	h.Write(prefix[:])
 	h.Write(message)
+
+	var messageDigest, hramDigest [64]byte
 	h.Sum(messageDigest[:0])
 
 	var messageDigestReduced [32]byte
@@ -62,15 +73,16 @@ func Sign(message []byte, priKey *[64]byte) *[64]byte {
 	R.ToBytes(&encodedR)
 
 	h.Reset()
+	h.Write(dom)
 	h.Write(encodedR[:])
-	h.Write(priKey[32:])
+	h.Write(pubKey)
 	h.Write(message)
 	h.Sum(hramDigest[:0])
 	var hramDigestReduced [32]byte
 	edwards25519.ScReduce(&hramDigestReduced, &hramDigest)
 
 	var s [32]byte
-	edwards25519.ScMulAdd(&s, &hramDigestReduced, &expandedSecretKey, &messageDigestReduced)
+	edwards25519.ScMulAdd(&s, &hramDigestReduced, expandedSecretKey, &messageDigestReduced)
 
 	signature := new([64]byte)
 	copy(signature[:], encodedR[:])
@@ -0,0 +1,103 @@
+package ed25519
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+//	indices from 2^31 are hardened
+const HardenedOffset = 0x80000000
+
+var (
+	ErrSeedSize    = errors.New("[ED25519] seed must be 16 to 64 bytes")
+	ErrInvalidPath = errors.New("[ED25519] invalid key derivation path")
+	ErrNotHardened = errors.New("[ED25519] SLIP-0010 derives only hardened ed25519 keys")
+)
+
+//	SLIP-0010 node, Key is the seed of the ed25519 private key
+type SLIP10Key struct {
+	Key       [32]byte
+	ChainCode [32]byte
+}
+
+//	SLIP-0010 master key, I = HMAC-SHA512("ed25519 seed", seed)
+func NewSLIP10MasterKey(seed []byte) (*SLIP10Key, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, ErrSeedSize
+	}
+	return newSLIP10Key([]byte("ed25519 seed"), seed), nil
+}
+
+//	hardened child at index, I = HMAC-SHA512(c, 0x00 || k || index)
+func (k *SLIP10Key) Child(index uint32) (*SLIP10Key, error) {
+	if index < HardenedOffset {
+		return nil, ErrNotHardened
+	}
+	data := make([]byte, 37)
+	copy(data[1:33], k.Key[:])
+	binary.BigEndian.PutUint32(data[33:], index)
+	return newSLIP10Key(k.ChainCode[:], data), nil
+}
+
+//	ed25519 private key and public key of the node
+func (k *SLIP10Key) PrivateKey() (priKey *[64]byte, pubKey *[32]byte) {
+	return GenerateKey(bytes.NewReader(k.Key[:]))
+}
+
+//	derive SLIP-0010 key from seed along a path such as m/44'/501'/0'/0'
+func DeriveSLIP10Key(seed []byte, path string) (*SLIP10Key, error) {
+	indices, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := NewSLIP10MasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	for _, index := range indices {
+		if key, err = key.Child(index); err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+//	parse a BIP-32 path, hardened levels end with ' or H
+func ParsePath(path string) ([]uint32, error) {
+	levels := strings.Split(path, "/")
+	if levels[0] != "m" {
+		return nil, ErrInvalidPath
+	}
+
+	indices := make([]uint32, 0, len(levels)-1)
+	for _, level := range levels[1:] {
+		offset := uint32(0)
+		if strings.HasSuffix(level, "'") || strings.HasSuffix(level, "H") {
+			level = level[:len(level)-1]
+			offset = HardenedOffset
+		}
+		index, err := strconv.ParseUint(level, 10, 31)
+		if err != nil {
+			return nil, ErrInvalidPath
+		}
+		indices = append(indices, uint32(index)+offset)
+	}
+	return indices, nil
+}
+
+func newSLIP10Key(key []byte, data []byte) *SLIP10Key {
+	mac := hmac.New(sha512.New, key)
+	mac.Write(data)
+	I := mac.Sum(nil)
+
+	k := new(SLIP10Key)
+	copy(k.Key[:], I[:32])
+	copy(k.ChainCode[:], I[32:])
+	return k
+}
@@ -0,0 +1,92 @@
+package ed25519
+
+import (
+	"crypto/sha512"
+	"errors"
+	"io"
+)
+
+//	maximal length of the context string of Ed25519ctx and Ed25519ph
+const MaxContextSize = 255
+
+var ErrContextSize = errors.New("[ED25519] bad context size")
+
+//	prefix of the hashes of Ed25519ctx and Ed25519ph
+const domPrefix = "SigEd25519 no Ed25519 collisions"
+
+//	dom2(phflag, context) = "SigEd25519 no Ed25519 collisions" || phflag || len(context) || context
+func dom2(phflag byte, context []byte) []byte {
+	dom := make([]byte, 0, len(domPrefix)+2+len(context))
+	dom = append(dom, domPrefix...)
+	dom = append(dom, phflag, byte(len(context)))
+	return append(dom, context...)
+}
+
+//	Ed25519ctx signature, context must be 1 to 255 bytes
+func SignCtx(message []byte, context []byte, priKey *[64]byte) (*[64]byte, error) {
+	if len(context) == 0 || len(context) > MaxContextSize {
+		return nil, ErrContextSize
+	}
+	return sign(dom2(0, context), message, priKey), nil
+}
+
+//	verify Ed25519ctx signature under the rules of VerifyStrict
+func VerifyCtx(message []byte, context []byte, pubKey *[32]byte, signature *[64]byte) bool {
+	if len(context) == 0 || len(context) > MaxContextSize {
+		return false
+	}
+	return verify(dom2(0, context), message, pubKey, signature, true)
+}
+
+//	Ed25519ph signature of SHA-512(message), context may be empty
+func SignPh(message []byte, context []byte, priKey *[64]byte) (*[64]byte, error) {
+	digest := sha512.Sum512(message)
+	return signPh(digest[:], context, priKey)
+}
+
+//	verify Ed25519ph signature under the rules of VerifyStrict
+func VerifyPh(message []byte, context []byte, pubKey *[32]byte, signature *[64]byte) bool {
+	digest := sha512.Sum512(message)
+	return verifyPh(digest[:], context, pubKey, signature)
+}
+
+//	Ed25519ph signature of a message read from r, the message is hashed as it is read
+//	and never held in memory
+func SignPhReader(r io.Reader, context []byte, priKey *[64]byte) (*[64]byte, error) {
+	digest, err := hashReader(r)
+	if err != nil {
+		return nil, err
+	}
+	return signPh(digest, context, priKey)
+}
+
+//	verify Ed25519ph signature of a message read from r
+func VerifyPhReader(r io.Reader, context []byte, pubKey *[32]byte, signature *[64]byte) (bool, error) {
+	digest, err := hashReader(r)
+	if err != nil {
+		return false, err
+	}
+	return verifyPh(digest, context, pubKey, signature), nil
+}
+
+func signPh(digest []byte, context []byte, priKey *[64]byte) (*[64]byte, error) {
+	if len(context) > MaxContextSize {
+		return nil, ErrContextSize
+	}
+	return sign(dom2(1, context), digest, priKey), nil
+}
+
+func verifyPh(digest []byte, context []byte, pubKey *[32]byte, signature *[64]byte) bool {
+	if len(context) > MaxContextSize {
+		return false
+	}
+	return verify(dom2(1, context), digest, pubKey, signature, true)
+}
+
+func hashReader(r io.Reader) ([]byte, error) {
+	h := sha512.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
@@ -0,0 +1,134 @@
+package ed25519
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+)
+
+//	RFC 8032 section 7.2, Ed25519ctx
+var ctxVectors = []struct {
+	secretKey, publicKey, message, context, signature string
+}{
+	{"0305334e381af78f141cb666f6199f57bc3495335a256a95bd2a55bf546663f6", "dfc9425e4f968f7f0c29f0259cf5f9aed6851c2bb4ad8bfb860cfee0ab248292", "f726936d19c800494e3fdaff20b276a8", "666f6f", "55a4cc2f70a54e04288c5f4cd1e45a7bb520b36292911876cada7323198dd87a8b36950b95130022907a7fb7c4e9b2d5f6cca685a587b4b21f4b888e4e7edb0d"},
+	{"0305334e381af78f141cb666f6199f57bc3495335a256a95bd2a55bf546663f6", "dfc9425e4f968f7f0c29f0259cf5f9aed6851c2bb4ad8bfb860cfee0ab248292", "f726936d19c800494e3fdaff20b276a8", "626172", "fc60d5872fc46b3aa69f8b5b4351d5808f92bcc044606db097abab6dbcb1aee3216c48e8b3b66431b5b186d1d28f8ee15a5ca2df6668346291c2043d4eb3e90d"},
+	{"0305334e381af78f141cb666f6199f57bc3495335a256a95bd2a55bf546663f6", "dfc9425e4f968f7f0c29f0259cf5f9aed6851c2bb4ad8bfb860cfee0ab248292", "508e9e6882b979fea900f62adceaca35", "666f6f", "8b70c1cc8310e1de20ac53ce28ae6e7207f33c3295e03bb5c0732a1d20dc64908922a8b052cf99b7c4fe107a5abb5b2c4085ae75890d02df26269d8945f84b0b"},
+	{"ab9c2853ce297ddab85c993b3ae14bcad39b2c682beabc27d6d4eb20711d6560", "0f1d1274943b91415889152e893d80e93275a1fc0b65fd71b4b0dda10ad7d772", "f726936d19c800494e3fdaff20b276a8", "666f6f", "21655b5f1aa965996b3f97b3c849eafba922a0a62992f73b3d1b73106a84ad85e9b86a7b6005ea868337ff2d20a7f5fbd4cd10b0be49a68da2b2e0dc0ad8960f"},
+}
+
+//	RFC 8032 section 7.3, Ed25519ph
+var phVector = struct {
+	secretKey, publicKey, message, signature string
+}{"833fe62409237b9d62ec77587520911e9a759cec1d19755b7da901b96dca3d42", "ec172b93ad5e563bf4932c70e1245034c35467ef2efd4d64ebf819683467e2bf", "616263", "98a70222f0b8121aa9d30f813d683f809e462b469c7ff87639499bb94e6dae4131f85042463c2a355a2003d062adf5aaa10b8c61e636062aaad11c2a26083406"}
+
+//	private key of the secret key, the public key is checked against the vector
+func testKey(t *testing.T, secretKey, publicKey string) (*[64]byte, *[32]byte) {
+	seed, _ := hex.DecodeString(secretKey)
+	priKey, pubKey := GenerateKey(bytes.NewReader(seed))
+	if hex.EncodeToString(pubKey[:]) != publicKey {
+		t.Fatalf("got result %x but expected %v\n", pubKey[:], publicKey)
+	}
+	return priKey, pubKey
+}
+
+func TestCtx(t *testing.T) {
+	fmt.Println("Test : Ed25519ctx ...")
+
+	t0 := time.Now()
+
+	for _, v := range ctxVectors {
+		priKey, pubKey := testKey(t, v.secretKey, v.publicKey)
+		message, _ := hex.DecodeString(v.message)
+		context, _ := hex.DecodeString(v.context)
+
+		signature, err := SignCtx(message, context, priKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(signature[:]) != v.signature {
+			t.Fatalf("got result %x but expected %v\n", signature[:], v.signature)
+		}
+		if !VerifyCtx(message, context, pubKey, signature) {
+			t.Fatalf("got result %v but expected %v\n", false, true)
+		}
+
+		//	another context, PureEdDSA or Ed25519ph do not accept the signature
+		if VerifyCtx(message, []byte("baz"), pubKey, signature) || Verify(message, pubKey, signature) || VerifyPh(message, context, pubKey, signature) {
+			t.Fatalf("got result %v but expected %v\n", true, false)
+		}
+	}
+
+	priKey, pubKey := testKey(t, ctxVectors[0].secretKey, ctxVectors[0].publicKey)
+	for _, context := range [][]byte{nil, make([]byte, MaxContextSize+1)} {
+		if _, err := SignCtx([]byte("message"), context, priKey); err != ErrContextSize {
+			t.Fatalf("got result %v but expected %v\n", err, ErrContextSize)
+		}
+		if VerifyCtx([]byte("message"), context, pubKey, new([64]byte)) {
+			t.Fatalf("got result %v but expected %v\n", true, false)
+		}
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func TestPh(t *testing.T) {
+	fmt.Println("Test : Ed25519ph ...")
+
+	t0 := time.Now()
+
+	priKey, pubKey := testKey(t, phVector.secretKey, phVector.publicKey)
+	message, _ := hex.DecodeString(phVector.message)
+
+	signature, err := SignPh(message, nil, priKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(signature[:]) != phVector.signature {
+		t.Fatalf("got result %x but expected %v\n", signature[:], phVector.signature)
+	}
+	if !VerifyPh(message, nil, pubKey, signature) {
+		t.Fatalf("got result %v but expected %v\n", false, true)
+	}
+	if VerifyPh([]byte("abd"), nil, pubKey, signature) || VerifyPh(message, []byte("foo"), pubKey, signature) || Verify(message, pubKey, signature) {
+		t.Fatalf("got result %v but expected %v\n", true, false)
+	}
+
+	//	streaming a message of a few megabytes agrees with signing it in memory
+	context := []byte("file")
+	large := bytes.Repeat([]byte("0123456789abcdef"), 1<<18)
+	streamed, err := SignPhReader(bytes.NewReader(large), context, priKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature, _ = SignPh(large, context, priKey)
+	if *streamed != *signature {
+		t.Fatalf("got result %x but expected %x\n", streamed[:], signature[:])
+	}
+	result, err := VerifyPhReader(bytes.NewReader(large), context, pubKey, streamed)
+	if err != nil || !result {
+		t.Fatalf("got result %v but expected %v\n", result, true)
+	}
+	result, err = VerifyPhReader(bytes.NewReader(large[1:]), context, pubKey, streamed)
+	if err != nil || result {
+		t.Fatalf("got result %v but expected %v\n", result, false)
+	}
+
+	//	read errors are returned
+	if _, err := SignPhReader(errReader{}, context, priKey); err != io.ErrUnexpectedEOF {
+		t.Fatalf("got result %v but expected %v\n", err, io.ErrUnexpectedEOF)
+	}
+	if _, err := SignPh(message, make([]byte, MaxContextSize+1), priKey); err != ErrContextSize {
+		t.Fatalf("got result %v but expected %v\n", err, ErrContextSize)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+type errReader struct{}
+
+func (errReader) Read(buf []byte) (int, error) {
+	return 0, io.ErrUnexpectedEOF
+}
@@ -0,0 +1,82 @@
+package ed25519
+
+import (
+	"bytes"
+	"crypto/sha512"
+
+	"filippo.io/edwards25519"
+)
+
+//	rules of signature verification, implementations of Ed25519 disagree on
+//	non-canonical encodings, small order points and the cofactor
+type VerifyMode int
+
+const (
+	//	Verify: the top three bits of S must be clear, A may be non-canonical
+	//	and R is compared to [S]B - [k]A without the cofactor
+	VerifyLegacy VerifyMode = iota
+	//	RFC 8032 with the cofactored equation [8][S]B = [8]R + [8][k]A,
+	//	S < L, A and R canonical and not of small order
+	VerifyStrict
+	//	ZIP-215: S < L, A and R may be non-canonical or of small order and the
+	//	equation is cofactored, so that every verifier agrees on every signature
+	VerifyZIP215
+)
+
+//	verify signature under mode
+func VerifyWithMode(message []byte, pubKey *[32]byte, signature *[64]byte, mode VerifyMode) bool {
+	switch mode {
+	case VerifyLegacy:
+		return Verify(message, pubKey, signature)
+	case VerifyStrict, VerifyZIP215:
+	default:
+		return false
+	}
+	return verify(nil, message, pubKey, signature, mode == VerifyStrict)
+}
+
+//	cofactored verification with dom prefixed to the hash, strict or ZIP-215 decoding
+func verify(dom []byte, message []byte, pubKey *[32]byte, signature *[64]byte, strict bool) bool {
+	S, err := new(edwards25519.Scalar).SetCanonicalBytes(signature[32:])
+	if err != nil {
+		return false
+	}
+	A, ok := decodePoint(pubKey[:], strict)
+	if !ok {
+		return false
+	}
+	R, ok := decodePoint(signature[:32], strict)
+	if !ok {
+		return false
+	}
+
+	// k = H(dom || R || A || M) over the encodings as received
+	h := sha512.New()
+	h.Write(dom)
+	h.Write(signature[:32])
+	h.Write(pubKey[:])
+	h.Write(message)
+	k, _ := new(edwards25519.Scalar).SetUniformBytes(h.Sum(nil))
+
+	// [8]([S]B - [k]A - R) == 0
+	minusA := new(edwards25519.Point).Negate(A)
+	check := new(edwards25519.Point).VarTimeDoubleScalarBaseMult(k, minusA, S)
+	check.Subtract(check, R)
+	return isSmallOrder(check)
+}
+
+//	decode point, strict decoding rejects non-canonical encodings and points of small order
+func decodePoint(b []byte, strict bool) (*edwards25519.Point, bool) {
+	P, err := new(edwards25519.Point).SetBytes(b)
+	if err != nil {
+		return nil, false
+	}
+	if strict && (!bytes.Equal(P.Bytes(), b) || isSmallOrder(P)) {
+		return nil, false
+	}
+	return P, true
+}
+
+func isSmallOrder(P *edwards25519.Point) bool {
+	return new(edwards25519.Point).MultByCofactor(P).Equal(edwards25519.NewIdentityPoint()) == 1
+}
@@ -0,0 +1,119 @@
+package ed25519
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+)
+
+//	"Taming the many EdDSAs", https://github.com/novifinancial/ed25519-speccheck
+var speccheckCases = []struct {
+	message, pubKey, signature string
+}{
+	{"8c93255d71dcab10e8f379c26200f3c7bd5f09d9bc3068d3ef4edeb4853022b6", "c7176a703d4dd84fba3c0b760d10670f2a2053fa2c39ccc64ec7fd7792ac03fa", "c7176a703d4dd84fba3c0b760d10670f2a2053fa2c39ccc64ec7fd7792ac037a0000000000000000000000000000000000000000000000000000000000000000"},
+	{"9bd9f44f4dcc75bd531b56b2cd280b0bb38fc1cd6d1230e14861d861de092e79", "c7176a703d4dd84fba3c0b760d10670f2a2053fa2c39ccc64ec7fd7792ac03fa", "f7badec5b8abeaf699583992219b7b223f1df3fbbea919844e3f7c554a43dd43a5bb704786be79fc476f91d3f3f89b03984d8068dcf1bb7dfc6637b45450ac04"},
+	{"aebf3f2601a0c8c5d39cc7d8911642f740b78168218da8471772b35f9d35b9ab", "f7badec5b8abeaf699583992219b7b223f1df3fbbea919844e3f7c554a43dd43", "c7176a703d4dd84fba3c0b760d10670f2a2053fa2c39ccc64ec7fd7792ac03fa8c4bd45aecaca5b24fb97bc10ac27ac8751a7dfe1baff8b953ec9f5833ca260e"},
+	{"9bd9f44f4dcc75bd531b56b2cd280b0bb38fc1cd6d1230e14861d861de092e79", "cdb267ce40c5cd45306fa5d2f29731459387dbf9eb933b7bd5aed9a765b88d4d", "9046a64750444938de19f227bb80485e92b83fdb4b6506c160484c016cc1852f87909e14428a7a1d62e9f22f3d3ad7802db02eb2e688b6c52fcd6648a98bd009"},
+	{"e47d62c63f830dc7a6851a0b1f33ae4bb2f507fb6cffec4011eaccd55b53f56c", "cdb267ce40c5cd45306fa5d2f29731459387dbf9eb933b7bd5aed9a765b88d4d", "160a1cb0dc9c0258cd0a7d23e94d8fa878bcb1925f2c64246b2dee1796bed5125ec6bc982a269b723e0668e540911a9a6a58921d6925e434ab10aa7940551a09"},
+	{"e47d62c63f830dc7a6851a0b1f33ae4bb2f507fb6cffec4011eaccd55b53f56c", "cdb267ce40c5cd45306fa5d2f29731459387dbf9eb933b7bd5aed9a765b88d4d", "21122a84e0b5fca4052f5b1235c80a537878b38f3142356b2c2384ebad4668b7e40bc836dac0f71076f9abe3a53f9c03c1ceeeddb658d0030494ace586687405"},
+	{"85e241a07d148b41e47d62c63f830dc7a6851a0b1f33ae4bb2f507fb6cffec40", "442aad9f089ad9e14647b1ef9099a1ff4798d78589e66f28eca69c11f582a623", "e96f66be976d82e60150baecff9906684aebb1ef181f67a7189ac78ea23b6c0e547f7690a0e2ddcd04d87dbc3490dc19b3b3052f7ff0538cb68afb369ba3a514"},
+	{"85e241a07d148b41e47d62c63f830dc7a6851a0b1f33ae4bb2f507fb6cffec40", "442aad9f089ad9e14647b1ef9099a1ff4798d78589e66f28eca69c11f582a623", "8ce5b96c8f26d0ab6c47958c9e68b937104cd36e13c33566acd2fe8d38aa19427e71f98a473474f2f13f06f97c20d58cc3f54b8bd0d272f42b695dd7e89a8c22"},
+	{"9bedc267423725d473888631ebf45988bad3db83851ee85c85e241a07d148b41", "f7badec5b8abeaf699583992219b7b223f1df3fbbea919844e3f7c554a43dd43", "ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff03be9678ac102edcd92b0210bb34d7428d12ffc5df5f37e359941266a4e35f0f"},
+	{"9bedc267423725d473888631ebf45988bad3db83851ee85c85e241a07d148b41", "f7badec5b8abeaf699583992219b7b223f1df3fbbea919844e3f7c554a43dd43", "ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffca8c5b64cd208982aa38d4936621a4775aa233aa0505711d8fdcfdaa943d4908"},
+	{"e96b7021eb39c1a163b6da4e3093dcd3f21387da4cc4572be588fafae23c155b", "ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", "a9d55260f765261eb9b84e106f665e00b867287a761990d7135963ee0a7d59dca5bb704786be79fc476f91d3f3f89b03984d8068dcf1bb7dfc6637b45450ac04"},
+	{"39a591f5321bbe07fd5a23dc2f39d025d74526615746727ceefd6e82ae65c06f", "ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", "a9d55260f765261eb9b84e106f665e00b867287a761990d7135963ee0a7d59dca5bb704786be79fc476f91d3f3f89b03984d8068dcf1bb7dfc6637b45450ac04"},
+}
+
+//	acceptance of each case under VerifyLegacy, VerifyStrict and VerifyZIP215
+var speccheckResults = [][3]bool{
+	{true, false, true},   // 0: small order A, small order R
+	{true, false, true},   // 1: small order A, mixed order R
+	{true, false, true},   // 2: mixed order A, small order R
+	{true, true, true},    // 3: mixed order A, mixed order R
+	{false, true, true},   // 4: cofactored verify
+	{false, true, true},   // 5: cofactored verify computes 8(hA) instead of (8h mod L)A
+	{true, false, false},  // 6: non-canonical S (S > L)
+	{false, false, false}, // 7: non-canonical S (S >> L)
+	{false, false, false}, // 8: mixed order A, non-canonical small order R, accepted if R is reduced before hashing
+	{false, false, true},  // 9: mixed order A, non-canonical small order R, accepted if R is not reduced before hashing
+	{false, false, true},  // 10: non-canonical small order A, mixed order R, accepted if cofactored or A is reduced before hashing
+	{true, false, true},   // 11: non-canonical small order A, mixed order R, accepted if cofactored or A is not reduced before hashing
+}
+
+//	the 14 encodings of points of small order, canonical or not, the ZIP-215 test set
+//	signs "Zcash" with every pair of them as A and R and S = 0
+var smallOrderEncodings = []string{
+	"0100000000000000000000000000000000000000000000000000000000000000",
+	"c7176a703d4dd84fba3c0b760d10670f2a2053fa2c39ccc64ec7fd7792ac037a",
+	"0000000000000000000000000000000000000000000000000000000000000080",
+	"26e8958fc2b227b045c3f489f2ef98f0d5dfac05d3c63339b13802886d53fc05",
+	"ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+	"26e8958fc2b227b045c3f489f2ef98f0d5dfac05d3c63339b13802886d53fc85",
+	"0000000000000000000000000000000000000000000000000000000000000000",
+	"c7176a703d4dd84fba3c0b760d10670f2a2053fa2c39ccc64ec7fd7792ac03fa",
+	"0100000000000000000000000000000000000000000000000000000000000080",
+	"ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+	"edffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+	"edffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+	"eeffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+	"eeffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+}
+
+func TestVerifyModes(t *testing.T) {
+	fmt.Println("Test : verify modes against the speccheck vectors ...")
+
+	t0 := time.Now()
+
+	for i, c := range speccheckCases {
+		message, _ := hex.DecodeString(c.message)
+		var pubKey [32]byte
+		var signature [64]byte
+		hex.Decode(pubKey[:], []byte(c.pubKey))
+		hex.Decode(signature[:], []byte(c.signature))
+
+		for mode, wanted := range speccheckResults[i] {
+			result := VerifyWithMode(message, &pubKey, &signature, VerifyMode(mode))
+			if result != wanted {
+				t.Fatalf("case %d mode %d: got result %v but expected %v\n", i, mode, result, wanted)
+			}
+		}
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func TestVerifyZIP215(t *testing.T) {
+	fmt.Println("Test : verify ZIP-215 small order vectors ...")
+
+	t0 := time.Now()
+
+	message := []byte("Zcash")
+	for _, a := range smallOrderEncodings {
+		for _, r := range smallOrderEncodings {
+			var pubKey [32]byte
+			var signature [64]byte
+			hex.Decode(pubKey[:], []byte(a))
+			hex.Decode(signature[:32], []byte(r))
+
+			if !VerifyWithMode(message, &pubKey, &signature, VerifyZIP215) {
+				t.Fatalf("A %s R %s: got result %v but expected %v\n", a, r, false, true)
+			}
+			if VerifyWithMode(message, &pubKey, &signature, VerifyStrict) {
+				t.Fatalf("A %s R %s: got result %v but expected %v\n", a, r, true, false)
+			}
+		}
+	}
+
+	//	honest signatures pass every mode
+	var zero zeroReader
+	priKey, pubKey := GenerateKey(zero)
+	signature := Sign(message, priKey)
+	for _, mode := range []VerifyMode{VerifyLegacy, VerifyStrict, VerifyZIP215} {
+		if !VerifyWithMode(message, pubKey, signature, mode) {
+			t.Fatalf("mode %d: got result %v but expected %v\n", mode, false, true)
+		}
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
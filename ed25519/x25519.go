@@ -0,0 +1,83 @@
+package ed25519
+
+import (
+	"crypto/sha256"
+	"errors"
+	"io"
+	"log"
+
+	"github.com/yahoo/coname/ed25519/extra25519"
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/hkdf"
+)
+
+var (
+	ErrInvalidPublicKey = errors.New("[ED25519] public key is not a point of the curve")
+	ErrKeyExchange      = errors.New("[ED25519] key exchange with a point of small order")
+	ErrKeySize          = errors.New("[ED25519] shared key must be 1 to 8160 bytes")
+)
+
+//	generate X25519 private key and public key using randomness from rand,
+//	e.g. an ephemeral key to encrypt to the converted key of a signer
+func GenerateX25519Key(rand io.Reader) (priKey *[32]byte, pubKey *[32]byte) {
+	priKey = new([32]byte)
+	pubKey = new([32]byte)
+
+	_, err := io.ReadFull(rand, priKey[:])
+	if err != nil {
+		log.Fatal(err)
+	}
+	curve25519.ScalarBaseMult(pubKey, priKey)
+	return
+}
+
+//	X25519 private key of an ed25519 private key, the clamped first half of the hash of the seed
+func PrivateKeyToX25519(priKey *[64]byte) *[32]byte {
+	x := new([32]byte)
+	extra25519.PrivateKeyToCurve25519(x, priKey)
+	return x
+}
+
+//	X25519 public key of an ed25519 public key, u = (1 + y) / (1 - y)
+func PublicKeyToX25519(pubKey *[32]byte) (*[32]byte, error) {
+	x := new([32]byte)
+	if !extra25519.PublicKeyToCurve25519(x, pubKey) {
+		return nil, ErrInvalidPublicKey
+	}
+	return x, nil
+}
+
+//	X25519 Diffie-Hellman, the shared secret is rejected if it is zero,
+//	i.e. the public key of the peer is of small order
+func X25519(priKey *[32]byte, peerPubKey *[32]byte) (*[32]byte, error) {
+	shared, err := curve25519.X25519(priKey[:], peerPubKey[:])
+	if err != nil {
+		return nil, ErrKeyExchange
+	}
+	secret := new([32]byte)
+	copy(secret[:], shared)
+	return secret, nil
+}
+
+//	key of size bytes shared by the owner of priKey and the owner of peerPubKey, both ed25519 keys:
+//	HKDF-SHA256 of the X25519 shared secret with salt and info.
+//	the key is the same in both directions
+func SharedKey(priKey *[64]byte, peerPubKey *[32]byte, salt []byte, info []byte, size int) ([]byte, error) {
+	if size <= 0 || size > 255*sha256.Size {
+		return nil, ErrKeySize
+	}
+	peer, err := PublicKeyToX25519(peerPubKey)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := X25519(PrivateKeyToX25519(priKey), peer)
+	if err != nil {
+		return nil, err
+	}
+
+	key := make([]byte, size)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, secret[:], salt, info), key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
@@ -0,0 +1,123 @@
+package ed25519
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+
+	"filippo.io/edwards25519"
+)
+
+func TestX25519(t *testing.T) {
+	fmt.Println("Test : X25519 ...")
+
+	t0 := time.Now()
+
+	//	RFC 7748 section 6.1
+	var alice, alicePub, bob, bobPub [32]byte
+	hex.Decode(alice[:], []byte("77076d0a7318a57d3c16c17251b26645df4c2f87ebc0992ab177fba51db92c2a"))
+	hex.Decode(alicePub[:], []byte("8520f0098930a754748b7ddcb43ef75a0dbf3a0d26381af4eba4a98eaa9b4e6a"))
+	hex.Decode(bob[:], []byte("5dab087e624a8a4b79e17f8b83800ee66f3bb1292618b6fd1c2f8b27ff88e0eb"))
+	hex.Decode(bobPub[:], []byte("de9edb7d7b7dc1b4d35b61c2ece435373f8343c85b78674dadfc7e146f882b4f"))
+	wanted := "4a5d9d5ba4ce2de1728e3bf480350f25e07e21c947d19e3376f09b3c1e161742"
+
+	for _, pair := range [][2]*[32]byte{{&alice, &bobPub}, {&bob, &alicePub}} {
+		secret, err := X25519(pair[0], pair[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(secret[:]) != wanted {
+			t.Fatalf("got result %x but expected %v\n", secret[:], wanted)
+		}
+	}
+
+	//	points of small order are rejected
+	if _, err := X25519(&alice, new([32]byte)); err != ErrKeyExchange {
+		t.Fatalf("got result %v but expected %v\n", err, ErrKeyExchange)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func TestX25519Conversion(t *testing.T) {
+	fmt.Println("Test : ed25519 to X25519 key conversion ...")
+
+	t0 := time.Now()
+
+	priKey, pubKey := GenerateKey(rand.Reader)
+	x, err := PublicKeyToX25519(pubKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//	the converted public key is the Montgomery form of the point and the public key of the converted private key
+	A, _ := new(edwards25519.Point).SetBytes(pubKey[:])
+	if !bytes.Equal(x[:], A.BytesMontgomery()) {
+		t.Fatalf("got result %x but expected %x\n", x[:], A.BytesMontgomery())
+	}
+	ephemeral, ephemeralPub := GenerateX25519Key(rand.Reader)
+	s1, _ := X25519(PrivateKeyToX25519(priKey), ephemeralPub)
+	s2, _ := X25519(ephemeral, x)
+	if *s1 != *s2 {
+		t.Fatalf("got result %x but expected %x\n", s1[:], s2[:])
+	}
+
+	//	y = 2 is not on the curve
+	if _, err := PublicKeyToX25519(&[32]byte{2}); err != ErrInvalidPublicKey {
+		t.Fatalf("got result %v but expected %v\n", err, ErrInvalidPublicKey)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func TestSharedKey(t *testing.T) {
+	fmt.Println("Test : ed25519 shared key ...")
+
+	t0 := time.Now()
+
+	alice, alicePub := GenerateKey(rand.Reader)
+	bob, bobPub := GenerateKey(rand.Reader)
+	_, evePub := GenerateKey(rand.Reader)
+
+	salt, info := []byte("salt"), []byte("go-cryptology session")
+	k1, err := SharedKey(alice, bobPub, salt, info, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k2, err := SharedKey(bob, alicePub, salt, info, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(k1, k2) {
+		t.Fatalf("got result %x but expected %x\n", k1, k2)
+	}
+
+	//	another peer or info gives another key
+	k3, _ := SharedKey(alice, evePub, salt, info, 32)
+	k4, _ := SharedKey(alice, bobPub, salt, []byte("another session"), 32)
+	if bytes.Equal(k1, k3) || bytes.Equal(k1, k4) {
+		t.Fatalf("got result %x but expected a different key\n", k1)
+	}
+
+	//	one identity key signs and agrees on keys
+	signature := Sign(k1, alice)
+	if !Verify(k1, alicePub, signature) {
+		t.Fatalf("got result %v but expected %v\n", false, true)
+	}
+
+	for _, size := range []int{0, 255*32 + 1} {
+		if _, err := SharedKey(alice, bobPub, salt, info, size); err != ErrKeySize {
+			t.Fatalf("got result %v but expected %v\n", err, ErrKeySize)
+		}
+	}
+	//	the identity encodes as y = 1 and has no Montgomery form
+	identity := [32]byte{1}
+	if _, err := SharedKey(alice, &identity, salt, info, 32); err != ErrKeyExchange {
+		t.Fatalf("got result %v but expected %v\n", err, ErrKeyExchange)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
@@ -0,0 +1,47 @@
+package ed448
+
+import (
+	"io"
+	"log"
+
+	"github.com/cloudflare/circl/sign/ed448"
+	"golang.org/x/crypto/sha3"
+)
+
+//	generate private key and public key using randomness from rand
+func GenerateKey(rand io.Reader) (priKey *[114]byte, pubKey *[57]byte) {
+	priKey = new([114]byte)
+	pubKey = new([57]byte)
+
+	_, err := io.ReadFull(rand, priKey[:ed448.SeedSize])
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	copy(priKey[:], ed448.NewKeyFromSeed(priKey[:ed448.SeedSize]))
+	copy(pubKey[:], priKey[ed448.SeedSize:])
+	return
+}
+
+//	digital signature, Ed448 with an empty context
+func Sign(message []byte, priKey *[114]byte) *[114]byte {
+	return toSignature(ed448.Sign(ed448.PrivateKey(priKey[:]), message, ""))
+}
+
+//	verify signature of Sign
+func Verify(message []byte, pubKey *[57]byte, signature *[114]byte) bool {
+	return ed448.Verify(ed448.PublicKey(pubKey[:]), message, signature[:], "")
+}
+
+func toSignature(b []byte) *[114]byte {
+	signature := new([114]byte)
+	copy(signature[:], b)
+	return signature
+}
+
+//	hash data, 114 bytes of SHAKE256
+func Hash(data []byte) []byte {
+	digest := make([]byte, 114)
+	sha3.ShakeSum256(digest, data)
+	return digest
+}
@@ -0,0 +1,44 @@
+package ed448
+
+import (
+	"errors"
+
+	"github.com/cloudflare/circl/sign/ed448"
+)
+
+//	maximal length of the context string
+const MaxContextSize = ed448.ContextMaxSize
+
+var ErrContextSize = errors.New("[ED448] bad context size")
+
+//	Ed448 signature with a context of at most 255 bytes
+func SignCtx(message []byte, context []byte, priKey *[114]byte) (*[114]byte, error) {
+	if len(context) > MaxContextSize {
+		return nil, ErrContextSize
+	}
+	return toSignature(ed448.Sign(ed448.PrivateKey(priKey[:]), message, string(context))), nil
+}
+
+//	verify Ed448 signature with a context
+func VerifyCtx(message []byte, context []byte, pubKey *[57]byte, signature *[114]byte) bool {
+	if len(context) > MaxContextSize {
+		return false
+	}
+	return ed448.Verify(ed448.PublicKey(pubKey[:]), message, signature[:], string(context))
+}
+
+//	Ed448ph signature of SHAKE256(message, 64), context may be empty
+func SignPh(message []byte, context []byte, priKey *[114]byte) (*[114]byte, error) {
+	if len(context) > MaxContextSize {
+		return nil, ErrContextSize
+	}
+	return toSignature(ed448.SignPh(ed448.PrivateKey(priKey[:]), message, string(context))), nil
+}
+
+//	verify Ed448ph signature
+func VerifyPh(message []byte, context []byte, pubKey *[57]byte, signature *[114]byte) bool {
+	if len(context) > MaxContextSize {
+		return false
+	}
+	return ed448.VerifyPh(ed448.PublicKey(pubKey[:]), message, signature[:], string(context))
+}
@@ -3,12 +3,16 @@ module go-cryptology
 go 1.13
 
 require (
+	filippo.io/edwards25519 v1.1.0
 	github.com/NebulousLabs/merkletree v0.0.0-20181203152040-08d5d54b07f5
 	github.com/btcsuite/btcd v0.22.0-beta
 	github.com/cbergoon/merkletree v0.2.0
+	github.com/cloudflare/circl v1.3.7
 	github.com/hbakhtiyor/schnorr v0.1.0
 	github.com/klauspost/reedsolomon v1.9.15
 	github.com/phoreproject/bls v0.0.0-20200525203911-a88a5ae26844
+	github.com/umbracle/go-eth-bn256 v0.0.0-20190607160430-b36caf4e0f6b
 	github.com/yahoo/coname v0.0.0-20170609175141-84592ddf8673
-	golang.org/x/crypto v0.0.0-20200510223506-06a226fb4e37
+	golang.org/x/crypto v0.17.0
+	golang.org/x/text v0.14.0
 )
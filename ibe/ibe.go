@@ -0,0 +1,188 @@
+package ibe
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"io"
+	"log"
+	"math/big"
+	"unsafe"
+
+	bls12 "github.com/phoreproject/bls"
+	"github.com/phoreproject/bls/g2pubs"
+	"go-cryptology/bls"
+)
+
+const (
+	SigmaSize = 32
+	//	compressed G2 point
+	USize = 96
+)
+
+var (
+	ErrDecryption = errors.New("[IBE] decryption failed")
+	ErrCiphertext = errors.New("[IBE] malformed ciphertext")
+)
+
+//	Boneh-Franklin FullIdent ciphertext
+type Ciphertext struct {
+	U *g2pubs.PublicKey
+	V [SigmaSize]byte
+	W []byte
+}
+
+//	generate master secret key and master public key, they are a BLS key pair
+func Setup() (masterKey *g2pubs.SecretKey, masterPubKey *g2pubs.PublicKey) {
+	return bls.GenBLSKey()
+}
+
+//	extract the private key of identity, it is the BLS signature of the identity
+func Extract(identity []byte, masterKey *g2pubs.SecretKey) *g2pubs.Signature {
+	return bls.Sign(identity, masterKey)
+}
+
+//	verify the private key of identity against the master public key
+func VerifyKey(identity []byte, masterPubKey *g2pubs.PublicKey, identityKey *g2pubs.Signature) bool {
+	return bls.Verify(identity, masterPubKey, identityKey)
+}
+
+//	encrypt message to identity
+func Encrypt(message []byte, identity []byte, masterPubKey *g2pubs.PublicKey) *Ciphertext {
+	var sigma [SigmaSize]byte
+	if _, err := io.ReadFull(rand.Reader, sigma[:]); err != nil {
+		log.Fatalf("[IBE] generate sigma failed, %v\n", err)
+	}
+
+	r := hashToScalar(sigma[:], message)
+
+	// g_id^r = e(H1(id), P_pub)^r = e(H1(id), r * P_pub)
+	h := bls12.HashG1(identity).ToProjective()
+	gid := bls12.Pairing(h, masterPubKey.GetPoint().MulFR(r))
+
+	ciphertext := &Ciphertext{
+		U: g2pubs.NewPublicKeyFromG2(bls12.G2AffineOne.MulFR(r).ToAffine()),
+		W: make([]byte, len(message)),
+	}
+	mask := hashGT(gid)
+	for i := range sigma {
+		ciphertext.V[i] = sigma[i] ^ mask[i]
+	}
+	xorKeyStream(ciphertext.W, message, sigma[:])
+	return ciphertext
+}
+
+//	decrypt ciphertext with the private key of the identity
+func Decrypt(ciphertext *Ciphertext, identityKey *g2pubs.Signature) ([]byte, error) {
+	if ciphertext == nil || ciphertext.U == nil {
+		return nil, ErrCiphertext
+	}
+
+	// e(d_id, U) = e(s * H1(id), r * g2)
+	gid := bls12.Pairing(identityKey.GetPoint(), ciphertext.U.GetPoint())
+
+	var sigma [SigmaSize]byte
+	mask := hashGT(gid)
+	for i := range sigma {
+		sigma[i] = ciphertext.V[i] ^ mask[i]
+	}
+	message := make([]byte, len(ciphertext.W))
+	xorKeyStream(message, ciphertext.W, sigma[:])
+
+	//	Fujisaki-Okamoto check: U must be re-derivable from sigma and message
+	r := hashToScalar(sigma[:], message)
+	u := ciphertext.U.Serialize()
+	expected := bls12.CompressG2(bls12.G2AffineOne.MulFR(r).ToAffine())
+	if subtle.ConstantTimeCompare(u[:], expected[:]) != 1 {
+		return nil, ErrDecryption
+	}
+	return message, nil
+}
+
+//	serialize ciphertext as U || V || W
+func (c *Ciphertext) Serialize() []byte {
+	u := c.U.Serialize()
+	result := make([]byte, 0, USize+SigmaSize+len(c.W))
+	result = append(result, u[:]...)
+	result = append(result, c.V[:]...)
+	return append(result, c.W...)
+}
+
+//	deserialize ciphertext
+func DeserializeCiphertext(b []byte) (*Ciphertext, error) {
+	if len(b) < USize+SigmaSize {
+		return nil, ErrCiphertext
+	}
+
+	var u [USize]byte
+	copy(u[:], b[:USize])
+	U, err := g2pubs.DeserializePublicKey(u)
+	if err != nil {
+		return nil, ErrCiphertext
+	}
+
+	ciphertext := &Ciphertext{U: U, W: make([]byte, len(b)-USize-SigmaSize)}
+	copy(ciphertext.V[:], b[USize:USize+SigmaSize])
+	copy(ciphertext.W, b[USize+SigmaSize:])
+	return ciphertext, nil
+}
+
+//	same layout as the unexported fields of bls12.FQ6 and bls12.FQ12
+type fq6 struct {
+	c [3][2]bls12.FQ
+}
+
+type fq12 struct {
+	c0, c1 *fq6
+}
+
+//	H2: GT -> {0,1}^256, the 12 Fq coefficients are hashed as 48-byte big-endian values
+func hashGT(element *bls12.FQ12) []byte {
+	h := sha256.New()
+	h.Write([]byte("IBE-H2"))
+	e := (*fq12)(unsafe.Pointer(element))
+	for _, f := range []*fq6{e.c0, e.c1} {
+		for i := range f.c {
+			for j := range f.c[i] {
+				b := f.c[i][j].ToRepr().Bytes()
+				h.Write(b[:])
+			}
+		}
+	}
+	return h.Sum(nil)
+}
+
+//	H3: (sigma, M) -> Zr
+func hashToScalar(sigma []byte, message []byte) *bls12.FRRepr {
+	h := sha512.New()
+	h.Write([]byte("IBE-H3"))
+	h.Write(sigma)
+	h.Write(message)
+	r := new(big.Int).SetBytes(h.Sum(nil))
+	r.Mod(r, bls12.RFieldModulus.ToBig())
+
+	repr, err := bls12.FRReprFromBigInt(r)
+	if err != nil {
+		log.Fatalf("[IBE] convert scalar failed, %v\n", err)
+	}
+	return repr
+}
+
+//	H4: sigma -> {0,1}^len(M), SHA-256 in counter mode
+func xorKeyStream(dst []byte, src []byte, sigma []byte) {
+	var counter [8]byte
+	for offset := 0; offset < len(src); offset += sha256.Size {
+		binary.BigEndian.PutUint64(counter[:], uint64(offset/sha256.Size))
+		h := sha256.New()
+		h.Write([]byte("IBE-H4"))
+		h.Write(sigma)
+		h.Write(counter[:])
+		block := h.Sum(nil)
+		for i := 0; i < len(block) && offset+i < len(src); i++ {
+			dst[offset+i] = src[offset+i] ^ block[i]
+		}
+	}
+}
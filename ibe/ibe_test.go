@@ -0,0 +1,106 @@
+package ibe
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	fmt.Println("Test : ibe encrypt decrypt ...")
+
+	masterKey, masterPubKey := Setup()
+	identity := []byte("alice@example.com")
+
+	t0 := time.Now()
+
+	identityKey := Extract(identity, masterKey)
+	if !VerifyKey(identity, masterPubKey, identityKey) {
+		t.Fatalf("identity key failed to verify\n")
+	}
+
+	message := bytes.Repeat([]byte("hello world "), 10)
+	ciphertext := Encrypt(message, identity, masterPubKey)
+
+	result, err := Decrypt(ciphertext, identityKey)
+	if err != nil || !bytes.Equal(result, message) {
+		t.Fatalf("got message %q but expected %q, %v\n", result, message, err)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func TestDecryptWrongIdentity(t *testing.T) {
+	fmt.Println("Test : ibe decrypt fails for another identity ...")
+
+	masterKey, masterPubKey := Setup()
+
+	ciphertext := Encrypt([]byte("hello world"), []byte("alice"), masterPubKey)
+	if _, err := Decrypt(ciphertext, Extract([]byte("bob"), masterKey)); err != ErrDecryption {
+		t.Fatalf("got error %v but expected %v\n", err, ErrDecryption)
+	}
+}
+
+func TestDecryptTampered(t *testing.T) {
+	fmt.Println("Test : ibe decrypt rejects tampered ciphertext ...")
+
+	masterKey, masterPubKey := Setup()
+	identity := []byte("alice")
+
+	b := Encrypt([]byte("hello world"), identity, masterPubKey).Serialize()
+	b[len(b)-1] ^= 1
+
+	ciphertext, err := DeserializeCiphertext(b)
+	if err != nil {
+		t.Fatalf("deserialize ciphertext failed, %v\n", err)
+	}
+	if _, err := Decrypt(ciphertext, Extract(identity, masterKey)); err != ErrDecryption {
+		t.Fatalf("got error %v but expected %v\n", err, ErrDecryption)
+	}
+}
+
+func TestSerialize(t *testing.T) {
+	fmt.Println("Test : ibe ciphertext serialization ...")
+
+	masterKey, masterPubKey := Setup()
+	identity := []byte("alice")
+	message := []byte("hello world")
+
+	ciphertext, err := DeserializeCiphertext(Encrypt(message, identity, masterPubKey).Serialize())
+	if err != nil {
+		t.Fatalf("deserialize ciphertext failed, %v\n", err)
+	}
+	result, err := Decrypt(ciphertext, Extract(identity, masterKey))
+	if err != nil || !bytes.Equal(result, message) {
+		t.Fatalf("got message %q but expected %q, %v\n", result, message, err)
+	}
+
+	if _, err := DeserializeCiphertext(make([]byte, USize)); err != ErrCiphertext {
+		t.Fatalf("got error %v but expected %v\n", err, ErrCiphertext)
+	}
+}
+
+func BenchmarkEncrypt(b *testing.B) {
+	_, masterPubKey := Setup()
+	message := []byte("hello world")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Encrypt(message, []byte("alice"), masterPubKey)
+	}
+}
+
+func BenchmarkDecrypt(b *testing.B) {
+	masterKey, masterPubKey := Setup()
+	identity := []byte("alice")
+	ciphertext := Encrypt([]byte("hello world"), identity, masterPubKey)
+	identityKey := Extract(identity, masterKey)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := Decrypt(ciphertext, identityKey); err != nil {
+			b.Fatalf("decrypt failed")
+		}
+	}
+}
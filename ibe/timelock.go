@@ -0,0 +1,33 @@
+package ibe
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+
+	"github.com/phoreproject/bls/g2pubs"
+)
+
+var ErrInvalidBeacon = errors.New("[IBE] beacon signature does not match the round")
+
+//	identity of a beacon round, H(round). an unchained beacon signs it when the round
+//	is reached, see beacon.UnchainedRoundMessage
+func RoundIdentity(round uint64) []byte {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], round)
+	digest := sha256.Sum256(b[:])
+	return digest[:]
+}
+
+//	encrypt message so that it can only be decrypted once the beacon has signed round
+func EncryptToRound(message []byte, round uint64, beaconPubKey *g2pubs.PublicKey) *Ciphertext {
+	return Encrypt(message, RoundIdentity(round), beaconPubKey)
+}
+
+//	decrypt ciphertext with the (threshold) beacon signature of round
+func DecryptWithRound(ciphertext *Ciphertext, round uint64, beaconPubKey *g2pubs.PublicKey, beaconSignature *g2pubs.Signature) ([]byte, error) {
+	if !VerifyKey(RoundIdentity(round), beaconPubKey, beaconSignature) {
+		return nil, ErrInvalidBeacon
+	}
+	return Decrypt(ciphertext, beaconSignature)
+}
@@ -0,0 +1,49 @@
+package ibe
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"go-cryptology/bls"
+)
+
+func TestTimelock(t *testing.T) {
+	fmt.Println("Test : timelock encryption to a threshold beacon round ...")
+
+	beaconKey, beaconPubKey := Setup()
+	shares, _, err := bls.SplitKey(beaconKey, 2, 3)
+	if err != nil {
+		t.Fatalf("split key failed, %v\n", err)
+	}
+
+	t0 := time.Now()
+
+	const round = 42
+	message := []byte("sealed bid")
+	ciphertext := EncryptToRound(message, round, beaconPubKey)
+
+	//	the committee signs the round once it is reached
+	var partials []*bls.PartialSignature
+	for _, share := range shares[1:] {
+		partials = append(partials, bls.PartialSign(RoundIdentity(round), share))
+	}
+	beaconSignature, err := bls.RecoverSignature(partials, 2)
+	if err != nil {
+		t.Fatalf("recover signature failed, %v\n", err)
+	}
+
+	result, err := DecryptWithRound(ciphertext, round, beaconPubKey, beaconSignature)
+	if err != nil || !bytes.Equal(result, message) {
+		t.Fatalf("got message %q but expected %q, %v\n", result, message, err)
+	}
+
+	//	the signature of another round does not open the ciphertext
+	other := Extract(RoundIdentity(round+1), beaconKey)
+	if _, err := DecryptWithRound(ciphertext, round, beaconPubKey, other); err != ErrInvalidBeacon {
+		t.Fatalf("got error %v but expected %v\n", err, ErrInvalidBeacon)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
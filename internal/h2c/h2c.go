@@ -0,0 +1,57 @@
+package h2c
+
+import (
+	"errors"
+	"hash"
+)
+
+var ErrLength = errors.New("[H2C] requested length exceeds 255 hash outputs or 65535 bytes")
+
+//	prefix of the hash of a DST longer than 255 bytes
+const oversizeDSTPrefix = "H2C-OVERSIZE-DST-"
+
+//	RFC 9380 expand_message_xmd, a DST longer than 255 bytes is replaced by
+//	H("H2C-OVERSIZE-DST-" || DST), length is at most 255 * H.Size() and 65535
+func ExpandMessageXMD(newHash func() hash.Hash, msg []byte, dst []byte, length int) ([]byte, error) {
+	h := newHash()
+	bSize, rSize := h.Size(), h.BlockSize()
+	ell := (length + bSize - 1) / bSize
+	if length < 0 || ell > 255 || length > 65535 {
+		return nil, ErrLength
+	}
+
+	if len(dst) > 255 {
+		h.Write([]byte(oversizeDSTPrefix))
+		h.Write(dst)
+		dst = h.Sum(nil)
+		h.Reset()
+	}
+	dstPrime := append(append([]byte(nil), dst...), byte(len(dst)))
+
+	h.Write(make([]byte, rSize))
+	h.Write(msg)
+	h.Write([]byte{byte(length >> 8), byte(length), 0})
+	h.Write(dstPrime)
+	b0 := h.Sum(nil)
+
+	h.Reset()
+	h.Write(b0)
+	h.Write([]byte{1})
+	h.Write(dstPrime)
+	bi := h.Sum(nil)
+
+	uniform := append(make([]byte, 0, ell*bSize), bi...)
+	for i := 2; i <= ell; i++ {
+		chain := make([]byte, bSize)
+		for j := range chain {
+			chain[j] = b0[j] ^ bi[j]
+		}
+		h.Reset()
+		h.Write(chain)
+		h.Write([]byte{byte(i)})
+		h.Write(dstPrime)
+		bi = h.Sum(nil)
+		uniform = append(uniform, bi...)
+	}
+	return uniform[:length], nil
+}
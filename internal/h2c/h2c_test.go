@@ -0,0 +1,104 @@
+package h2c
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	q128 = "q128_" + strings.Repeat("q", 128)
+	a512 = "a512_" + strings.Repeat("a", 512)
+)
+
+type xmdVector struct {
+	msg     string
+	length  int
+	uniform string
+}
+
+//	RFC 9380 appendix K.1, K.2 and K.3, the DST of K.2 is longer than 255 bytes
+var xmdSuites = []struct {
+	newHash func() hash.Hash
+	dst     string
+	vectors []xmdVector
+}{
+	{
+		sha256.New,
+		"QUUX-V01-CS02-with-expander-SHA256-128",
+		[]xmdVector{
+			{"", 0x20, "68a985b87eb6b46952128911f2a4412bbc302a9d759667f87f7a21d803f07235"},
+			{"abc", 0x20, "d8ccab23b5985ccea865c6c97b6e5b8350e794e603b4b97902f53a8a0d605615"},
+			{"abcdef0123456789", 0x20, "eff31487c770a893cfb36f912fbfcbff40d5661771ca4b2cb4eafe524333f5c1"},
+			{q128, 0x20, "b23a1d2b4d97b2ef7785562a7e8bac7eed54ed6e97e29aa51bfe3f12ddad1ff9"},
+			{a512, 0x20, "4623227bcc01293b8c130bf771da8c298dede7383243dc0993d2d94823958c4c"},
+			{"", 0x80, "af84c27ccfd45d41914fdff5df25293e221afc53d8ad2ac06d5e3e29485dadbee0d121587713a3e0dd4d5e69e93eb7cd4f5df4cd103e188cf60cb02edc3edf18eda8576c412b18ffb658e3dd6ec849469b979d444cf7b26911a08e63cf31f9dcc541708d3491184472c2c29bb749d4286b004ceb5ee6b9a7fa5b646c993f0ced"},
+			{"abc", 0x80, "abba86a6129e366fc877aab32fc4ffc70120d8996c88aee2fe4b32d6c7b6437a647e6c3163d40b76a73cf6a5674ef1d890f95b664ee0afa5359a5c4e07985635bbecbac65d747d3d2da7ec2b8221b17b0ca9dc8a1ac1c07ea6a1e60583e2cb00058e77b7b72a298425cd1b941ad4ec65e8afc50303a22c0f99b0509b4c895f40"},
+		},
+	},
+	{
+		sha256.New,
+		"QUUX-V01-CS02-with-expander-SHA256-128-long-DST-" + strings.Repeat("1", 208),
+		[]xmdVector{
+			{"", 0x20, "e8dc0c8b686b7ef2074086fbdd2f30e3f8bfbd3bdf177f73f04b97ce618a3ed3"},
+			{"abc", 0x20, "52dbf4f36cf560fca57dedec2ad924ee9c266341d8f3d6afe5171733b16bbb12"},
+			{"abcdef0123456789", 0x20, "35387dcf22618f3728e6c686490f8b431f76550b0b2c61cbc1ce7001536f4521"},
+			{q128, 0x20, "01b637612bb18e840028be900a833a74414140dde0c4754c198532c3a0ba42bc"},
+			{a512, 0x20, "20cce7033cabc5460743180be6fa8aac5a103f56d481cf369a8accc0c374431b"},
+			{"", 0x80, "14604d85432c68b757e485c8894db3117992fc57e0e136f71ad987f789a0abc287c47876978e2388a02af86b1e8d1342e5ce4f7aaa07a87321e691f6fba7e0072eecc1218aebb89fb14a0662322d5edbd873f0eb35260145cd4e64f748c5dfe60567e126604bcab1a3ee2dc0778102ae8a5cfd1429ebc0fa6bf1a53c36f55dfc"},
+			{"abc", 0x80, "1a30a5e36fbdb87077552b9d18b9f0aee16e80181d5b951d0471d55b66684914aef87dbb3626eaabf5ded8cd0686567e503853e5c84c259ba0efc37f71c839da2129fe81afdaec7fbdc0ccd4c794727a17c0d20ff0ea55e1389d6982d1241cb8d165762dbc39fb0cee4474d2cbbd468a835ae5b2f20e4f959f56ab24cd6fe267"},
+		},
+	},
+	{
+		sha512.New,
+		"QUUX-V01-CS02-with-expander-SHA512-256",
+		[]xmdVector{
+			{"", 0x20, "6b9a7312411d92f921c6f68ca0b6380730a1a4d982c507211a90964c394179ba"},
+			{"abc", 0x20, "0da749f12fbe5483eb066a5f595055679b976e93abe9be6f0f6318bce7aca8dc"},
+			{"abcdef0123456789", 0x20, "087e45a86e2939ee8b91100af1583c4938e0f5fc6c9db4b107b83346bc967f58"},
+			{q128, 0x20, "7336234ee9983902440f6bc35b348352013becd88938d2afec44311caf8356b3"},
+			{a512, 0x20, "57b5f7e766d5be68a6bfe1768e3c2b7f1228b3e4b3134956dd73a59b954c66f4"},
+			{"", 0x80, "41b037d1734a5f8df225dd8c7de38f851efdb45c372887be655212d07251b921b052b62eaed99b46f72f2ef4cc96bfaf254ebbbec091e1a3b9e4fb5e5b619d2e0c5414800a1d882b62bb5cd1778f098b8eb6cb399d5d9d18f5d5842cf5d13d7eb00a7cff859b605da678b318bd0e65ebff70bec88c753b159a805d2c89c55961"},
+			{"abc", 0x80, "7f1dddd13c08b543f2e2037b14cefb255b44c83cc397c1786d975653e36a6b11bdd7732d8b38adb4a0edc26a0cef4bb45217135456e58fbca1703cd6032cb1347ee720b87972d63fbf232587043ed2901bce7f22610c0419751c065922b488431851041310ad659e4b23520e1772ab29dcdeb2002222a363f0c2b1c972b3efe1"},
+		},
+	},
+}
+
+func TestExpandMessageXMD(t *testing.T) {
+	fmt.Println("Test : h2c expand_message_xmd ...")
+
+	t0 := time.Now()
+
+	for _, suite := range xmdSuites {
+		for _, v := range suite.vectors {
+			uniform, err := ExpandMessageXMD(suite.newHash, []byte(v.msg), []byte(suite.dst), v.length)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if hex.EncodeToString(uniform) != v.uniform {
+				t.Fatalf("got result %x but expected %v\n", uniform, v.uniform)
+			}
+		}
+	}
+
+	//	at most 255 blocks of the hash
+	dst := []byte(xmdSuites[0].dst)
+	if _, err := ExpandMessageXMD(sha256.New, nil, dst, 255*32); err != nil {
+		t.Fatalf("got result %v but expected %v\n", err, nil)
+	}
+	for _, length := range []int{255*32 + 1, -1} {
+		if _, err := ExpandMessageXMD(sha256.New, nil, dst, length); err != ErrLength {
+			t.Fatalf("got result %v but expected %v\n", err, ErrLength)
+		}
+	}
+	if _, err := ExpandMessageXMD(sha512.New, nil, dst, 65536); err != ErrLength {
+		t.Fatalf("got result %v but expected %v\n", err, ErrLength)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
@@ -0,0 +1,90 @@
+package kzg
+
+import (
+	"math/big"
+
+	"go-cryptology/reedsolomon"
+)
+
+//	bytes per field element, a chunk prefixed with its length always fits below the field order
+const ChunkSize = 31
+
+//	interpolate the polynomial whose evaluation at x = i is the i-th 31-byte chunk of data.
+//	the evaluation is len(chunk) || chunk, so the chunk length is committed as well
+func BlobToPolynomial(data []byte) (Polynomial, error) {
+	n := (len(data) + ChunkSize - 1) / ChunkSize
+	xs := make([]*big.Int, n)
+	ys := make([]*big.Int, n)
+	for i := 0; i < n; i++ {
+		xs[i] = big.NewInt(int64(i))
+		end := (i + 1) * ChunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+		ys[i] = chunkScalar(data[i*ChunkSize : end])
+	}
+	return Interpolate(xs, ys)
+}
+
+//	commit to data, the polynomial is returned to open chunks later
+func CommitBlob(setup *Setup, data []byte) (*Commitment, Polynomial, error) {
+	p, err := BlobToPolynomial(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	commitment, err := Commit(setup, p)
+	if err != nil {
+		return nil, nil, err
+	}
+	return commitment, p, nil
+}
+
+//	prove the i-th chunk of the committed data
+func OpenChunk(setup *Setup, p Polynomial, i int) (*Proof, error) {
+	_, proof, err := Open(setup, p, big.NewInt(int64(i)))
+	return proof, err
+}
+
+//	verify the i-th chunk of the committed data, the last chunk may be shorter than ChunkSize.
+//	the length is part of the evaluation, leading zero bytes can not be added or stripped
+func VerifyChunk(setup *Setup, commitment *Commitment, i int, chunk []byte, proof *Proof) bool {
+	if len(chunk) > ChunkSize {
+		return false
+	}
+	return Verify(setup, commitment, big.NewInt(int64(i)), chunkScalar(chunk), proof)
+}
+
+//	erasure code data with reed-solomon and commit to every shard,
+//	any dataShards shards reconstruct the data and each one can be checked against its commitment
+func EncodeAndCommit(setup *Setup, dataShards int, parityShards int, data []byte) ([][]byte, []*Commitment, error) {
+	enc := rs.MakeEncoder(dataShards, parityShards)
+	//	the shards alias the input of Split, keep the caller's data untouched
+	shards := rs.Split(enc, append([]byte(nil), data...))
+	rs.Encode(enc, shards)
+
+	commitments := make([]*Commitment, len(shards))
+	for i, shard := range shards {
+		commitment, _, err := CommitBlob(setup, shard)
+		if err != nil {
+			return nil, nil, err
+		}
+		commitments[i] = commitment
+	}
+	return shards, commitments, nil
+}
+
+//	verify a shard against its commitment
+func VerifyShard(setup *Setup, shard []byte, commitment *Commitment) bool {
+	c, _, err := CommitBlob(setup, shard)
+	if err != nil {
+		return false
+	}
+	return c.Equal(commitment)
+}
+
+//	len(chunk) || chunk, at most 2^253 and below the field order
+func chunkScalar(chunk []byte) *big.Int {
+	temp := make([]byte, 0, len(chunk)+1)
+	temp = append(temp, byte(len(chunk)))
+	return new(big.Int).SetBytes(append(temp, chunk...))
+}
@@ -0,0 +1,98 @@
+package kzg
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"go-cryptology/reedsolomon"
+)
+
+func TestBlobChunk(t *testing.T) {
+	fmt.Println("Test : kzg open blob chunk ...")
+
+	data := bytes.Repeat([]byte("data availability "), 20)
+	commitment, p, err := CommitBlob(testSetup, data)
+	if err != nil {
+		t.Fatalf("commit blob failed, %v\n", err)
+	}
+
+	t0 := time.Now()
+
+	for _, i := range []int{0, 5, 11} {
+		proof, err := OpenChunk(testSetup, p, i)
+		if err != nil {
+			t.Fatalf("open chunk failed, %v\n", err)
+		}
+		end := (i + 1) * ChunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+		if !VerifyChunk(testSetup, commitment, i, data[i*ChunkSize:end], proof) {
+			t.Fatalf("chunk %d failed to verify\n", i)
+		}
+		if VerifyChunk(testSetup, commitment, i, []byte("forged"), proof) {
+			t.Fatalf("forged chunk %d verified\n", i)
+		}
+	}
+
+	//	leading zero bytes can be neither added nor stripped
+	data = append([]byte{0, 0}, []byte("leading zeros")...)
+	commitment, p, err = CommitBlob(testSetup, data)
+	if err != nil {
+		t.Fatalf("commit blob failed, %v\n", err)
+	}
+	proof, err := OpenChunk(testSetup, p, 0)
+	if err != nil {
+		t.Fatalf("open chunk failed, %v\n", err)
+	}
+	if !VerifyChunk(testSetup, commitment, 0, data, proof) {
+		t.Fatalf("chunk %d failed to verify\n", 0)
+	}
+	for _, chunk := range [][]byte{data[2:], append([]byte{0}, data...)} {
+		if VerifyChunk(testSetup, commitment, 0, chunk, proof) {
+			t.Fatalf("chunk %x verified but the committed chunk is %x\n", chunk, data)
+		}
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func TestEncodeAndCommit(t *testing.T) {
+	fmt.Println("Test : kzg commit erasure-coded shards ...")
+
+	data := bytes.Repeat([]byte("erasure coded blob "), 16)
+	shards, commitments, err := EncodeAndCommit(testSetup, 4, 2, data)
+	if err != nil {
+		t.Fatalf("encode and commit failed, %v\n", err)
+	}
+
+	for i, shard := range shards {
+		if !VerifyShard(testSetup, shard, commitments[i]) {
+			t.Fatalf("shard %d failed to verify\n", i)
+		}
+	}
+
+	//	a corrupted shard is detected before reconstruction
+	shards[1][0] ^= 1
+	if VerifyShard(testSetup, shards[1], commitments[1]) {
+		t.Fatalf("corrupted shard verified\n")
+	}
+
+	//	drop the corrupted and one more shard, then reconstruct
+	shards[1], shards[4] = nil, nil
+	enc := rs.MakeEncoder(4, 2)
+	rs.Reconstruct(enc, shards)
+	for i, shard := range shards {
+		if !VerifyShard(testSetup, shard, commitments[i]) {
+			t.Fatalf("reconstructed shard %d failed to verify\n", i)
+		}
+	}
+
+	var buf bytes.Buffer
+	rs.Join(enc, &buf, shards, len(data))
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Fatalf("reconstructed data differs\n")
+	}
+}
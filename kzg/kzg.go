@@ -0,0 +1,176 @@
+package kzg
+
+import (
+	"errors"
+	"math/big"
+
+	bls12 "github.com/phoreproject/bls"
+)
+
+var (
+	ErrDegree     = errors.New("[KZG] polynomial degree exceeds the setup")
+	ErrBatchSize  = errors.New("[KZG] too many points for the setup")
+	ErrCommitment = errors.New("[KZG] malformed commitment")
+)
+
+//	commitment to a polynomial, [p(tau)]G1
+type Commitment struct {
+	point *bls12.G1Projective
+}
+
+//	opening proof, [q(tau)]G1 for the quotient polynomial q
+type Proof struct {
+	point *bls12.G1Projective
+}
+
+//	commit to polynomial
+func Commit(setup *Setup, p Polynomial) (*Commitment, error) {
+	point, err := evalInG1(setup, p)
+	if err != nil {
+		return nil, err
+	}
+	return &Commitment{point}, nil
+}
+
+//	open polynomial at z, returns y = p(z) and the proof
+func Open(setup *Setup, p Polynomial, z *big.Int) (*big.Int, *Proof, error) {
+	y := p.Eval(z)
+
+	// q(x) = (p(x) - y) / (x - z)
+	quotient, _ := p.Sub(Polynomial{y}).Div(Polynomial{new(big.Int).Neg(z), big.NewInt(1)})
+	point, err := evalInG1(setup, quotient)
+	if err != nil {
+		return nil, nil, err
+	}
+	return y, &Proof{point}, nil
+}
+
+//	verify p(z) = y: e(C - [y]G1, G2) == e(proof, [tau]G2 - [z]G2)
+func Verify(setup *Setup, commitment *Commitment, z *big.Int, y *big.Int, proof *Proof) bool {
+	if len(setup.G2) < 2 {
+		return false
+	}
+
+	lhs := commitment.point.Add(negG1(bls12.G1AffineOne.MulFR(scalarToRepr(y))))
+	rhs := setup.G2[1].Add(negG2(bls12.G2AffineOne.MulFR(scalarToRepr(z))))
+	return pairingEqual(lhs, bls12.G2ProjectiveOne, proof.point, rhs)
+}
+
+//	open polynomial at several points with a single proof
+func BatchOpen(setup *Setup, p Polynomial, zs []*big.Int) ([]*big.Int, *Proof, error) {
+	if len(zs) >= len(setup.G2) {
+		return nil, nil, ErrBatchSize
+	}
+
+	ys := make([]*big.Int, len(zs))
+	for i, z := range zs {
+		ys[i] = p.Eval(z)
+	}
+	interpolation, err := Interpolate(zs, ys)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// q(x) = (p(x) - I(x)) / Z(x)
+	quotient, _ := p.Sub(interpolation).Div(ZeroPolynomial(zs))
+	point, err := evalInG1(setup, quotient)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ys, &Proof{point}, nil
+}
+
+//	verify p(zs[i]) = ys[i] for all i: e(C - [I(tau)]G1, G2) == e(proof, [Z(tau)]G2)
+func BatchVerify(setup *Setup, commitment *Commitment, zs []*big.Int, ys []*big.Int, proof *Proof) bool {
+	if len(zs) != len(ys) || len(zs) == 0 || len(zs) >= len(setup.G2) {
+		return false
+	}
+
+	interpolation, err := Interpolate(zs, ys)
+	if err != nil {
+		return false
+	}
+	i, err := evalInG1(setup, interpolation)
+	if err != nil {
+		return false
+	}
+
+	z := bls12.G2ProjectiveZero.Copy()
+	for k, coefficient := range ZeroPolynomial(zs) {
+		z = z.Add(setup.G2[k].MulFR(scalarToRepr(coefficient)))
+	}
+
+	lhs := commitment.point.Add(negG1(i))
+	return pairingEqual(lhs, bls12.G2ProjectiveOne, proof.point, z)
+}
+
+//	serialize commitment in compressed form
+func (c *Commitment) Serialize() [48]byte {
+	return bls12.CompressG1(c.point.ToAffine())
+}
+
+//	deserialize commitment
+func DeserializeCommitment(b [48]byte) (*Commitment, error) {
+	p, err := bls12.DecompressG1(b)
+	if err != nil {
+		return nil, ErrCommitment
+	}
+	return &Commitment{p.ToProjective()}, nil
+}
+
+//	whether two commitments are equal
+func (c *Commitment) Equal(other *Commitment) bool {
+	return c.point.Equal(other.point)
+}
+
+//	serialize proof in compressed form
+func (p *Proof) Serialize() [48]byte {
+	return bls12.CompressG1(p.point.ToAffine())
+}
+
+//	deserialize proof
+func DeserializeProof(b [48]byte) (*Proof, error) {
+	p, err := bls12.DecompressG1(b)
+	if err != nil {
+		return nil, ErrCommitment
+	}
+	return &Proof{p.ToProjective()}, nil
+}
+
+//	[p(tau)]G1
+func evalInG1(setup *Setup, p Polynomial) (*bls12.G1Projective, error) {
+	if p.Degree() >= len(setup.G1) {
+		return nil, ErrDegree
+	}
+
+	result := bls12.G1ProjectiveZero.Copy()
+	for i := 0; i <= p.Degree(); i++ {
+		if p[i].Sign() == 0 {
+			continue
+		}
+		result = result.Add(setup.G1[i].MulFR(scalarToRepr(p[i])))
+	}
+	return result, nil
+}
+
+//	e(p1, q1) == e(p2, q2), the Miller loop of the library cannot take the point at infinity
+func pairingEqual(p1 *bls12.G1Projective, q1 *bls12.G2Projective, p2 *bls12.G1Projective, q2 *bls12.G2Projective) bool {
+	trivial1 := p1.IsZero() || q1.IsZero()
+	trivial2 := p2.IsZero() || q2.IsZero()
+	if trivial1 || trivial2 {
+		return trivial1 && trivial2
+	}
+	return bls12.CompareTwoPairings(p1, q1, p2, q2)
+}
+
+func negG1(p *bls12.G1Projective) *bls12.G1Projective {
+	neg := p.Copy()
+	neg.NegAssign()
+	return neg
+}
+
+func negG2(p *bls12.G2Projective) *bls12.G2Projective {
+	neg := p.ToAffine()
+	neg.NegAssign()
+	return neg.ToProjective()
+}
@@ -0,0 +1,190 @@
+package kzg
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	bls12 "github.com/phoreproject/bls"
+)
+
+var testSetup = GenerateSetup(16, 5)
+
+func randomPolynomial(n int) Polynomial {
+	p := make(Polynomial, n)
+	for i := range p {
+		p[i] = big.NewInt(int64(i*i + 7))
+	}
+	return p
+}
+
+func TestOpenVerify(t *testing.T) {
+	fmt.Println("Test : kzg open verify ...")
+
+	p := randomPolynomial(16)
+	commitment, err := Commit(testSetup, p)
+	if err != nil {
+		t.Fatalf("commit failed, %v\n", err)
+	}
+
+	t0 := time.Now()
+
+	z := big.NewInt(123456789)
+	y, proof, err := Open(testSetup, p, z)
+	if err != nil {
+		t.Fatalf("open failed, %v\n", err)
+	}
+
+	result := Verify(testSetup, commitment, z, y, proof)
+	wanted := true
+	if result != wanted {
+		t.Fatalf("got result %v but expected %v\n", result, wanted)
+	}
+
+	//	a wrong value must not verify
+	if Verify(testSetup, commitment, z, new(big.Int).Add(y, big.NewInt(1)), proof) {
+		t.Fatalf("wrong evaluation verified\n")
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func TestOpenConstant(t *testing.T) {
+	fmt.Println("Test : kzg open constant polynomial ...")
+
+	p := Polynomial{big.NewInt(5)}
+	commitment, _ := Commit(testSetup, p)
+	y, proof, _ := Open(testSetup, p, big.NewInt(3))
+	if y.Int64() != 5 || !Verify(testSetup, commitment, big.NewInt(3), y, proof) {
+		t.Fatalf("constant polynomial failed to verify\n")
+	}
+}
+
+func TestDegreeTooLarge(t *testing.T) {
+	fmt.Println("Test : kzg rejects polynomial larger than the setup ...")
+
+	if _, err := Commit(testSetup, randomPolynomial(17)); err != ErrDegree {
+		t.Fatalf("got error %v but expected %v\n", err, ErrDegree)
+	}
+}
+
+func TestBatchOpenVerify(t *testing.T) {
+	fmt.Println("Test : kzg batch open verify ...")
+
+	p := randomPolynomial(10)
+	commitment, _ := Commit(testSetup, p)
+
+	t0 := time.Now()
+
+	zs := []*big.Int{big.NewInt(1), big.NewInt(20), big.NewInt(300), big.NewInt(4000)}
+	ys, proof, err := BatchOpen(testSetup, p, zs)
+	if err != nil {
+		t.Fatalf("batch open failed, %v\n", err)
+	}
+
+	result := BatchVerify(testSetup, commitment, zs, ys, proof)
+	wanted := true
+	if result != wanted {
+		t.Fatalf("got result %v but expected %v\n", result, wanted)
+	}
+
+	ys[2] = new(big.Int).Add(ys[2], big.NewInt(1))
+	if BatchVerify(testSetup, commitment, zs, ys, proof) {
+		t.Fatalf("wrong evaluation verified\n")
+	}
+
+	if _, _, err := BatchOpen(testSetup, p, append(zs, big.NewInt(5))); err != ErrBatchSize {
+		t.Fatalf("got error %v but expected %v\n", err, ErrBatchSize)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func TestSetupSerialize(t *testing.T) {
+	fmt.Println("Test : kzg setup serialization ...")
+
+	var buf bytes.Buffer
+	if err := testSetup.Serialize(&buf); err != nil {
+		t.Fatalf("serialize setup failed, %v\n", err)
+	}
+	b := buf.Bytes()
+
+	setup, err := LoadSetup(bytes.NewReader(b))
+	if err != nil {
+		t.Fatalf("load setup failed, %v\n", err)
+	}
+
+	p := randomPolynomial(8)
+	c1, _ := Commit(testSetup, p)
+	c2, _ := Commit(setup, p)
+	if c1.Serialize() != c2.Serialize() {
+		t.Fatalf("loaded setup gives another commitment\n")
+	}
+
+	//	G2 powers from another tau are rejected
+	other := GenerateSetup(16, 5)
+	var mixed bytes.Buffer
+	(&Setup{G1: testSetup.G1, G2: other.G2}).Serialize(&mixed)
+	if _, err := LoadSetup(&mixed); err != ErrSetup {
+		t.Fatalf("got error %v but expected %v\n", err, ErrSetup)
+	}
+
+	//	a single higher power from another tau is rejected in either group
+	g1 := append([]*bls12.G1Projective(nil), testSetup.G1...)
+	g1[7] = other.G1[7]
+	g2 := append([]*bls12.G2Projective(nil), testSetup.G2...)
+	g2[3] = other.G2[3]
+	for _, setup := range []*Setup{{G1: g1, G2: testSetup.G2}, {G1: testSetup.G1, G2: g2}} {
+		var tampered bytes.Buffer
+		setup.Serialize(&tampered)
+		if _, err := LoadSetup(&tampered); err != ErrSetup {
+			t.Fatalf("got error %v but expected %v\n", err, ErrSetup)
+		}
+	}
+}
+
+func TestCommitmentSerialize(t *testing.T) {
+	fmt.Println("Test : kzg commitment serialization ...")
+
+	p := randomPolynomial(4)
+	commitment, _ := Commit(testSetup, p)
+	z := big.NewInt(9)
+	y, proof, _ := Open(testSetup, p, z)
+
+	c, err := DeserializeCommitment(commitment.Serialize())
+	if err != nil {
+		t.Fatalf("deserialize commitment failed, %v\n", err)
+	}
+	q, err := DeserializeProof(proof.Serialize())
+	if err != nil {
+		t.Fatalf("deserialize proof failed, %v\n", err)
+	}
+	if !Verify(testSetup, c, z, y, q) {
+		t.Fatalf("deserialized proof failed to verify\n")
+	}
+}
+
+func BenchmarkCommit(b *testing.B) {
+	p := randomPolynomial(16)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Commit(testSetup, p)
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	p := randomPolynomial(16)
+	commitment, _ := Commit(testSetup, p)
+	z := big.NewInt(42)
+	y, proof, _ := Open(testSetup, p, z)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if !Verify(testSetup, commitment, z, y, proof) {
+			b.Fatalf("verify failed")
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package kzg
+
+import (
+	"errors"
+	"math/big"
+
+	bls12 "github.com/phoreproject/bls"
+)
+
+var (
+	ErrDuplicatePoint = errors.New("[KZG] evaluation points must be distinct")
+	ErrPointCount     = errors.New("[KZG] number of points and values differ")
+)
+
+//	order of the BLS12-381 scalar field
+var order = bls12.RFieldModulus.ToBig()
+
+//	polynomial over the scalar field, coefficients are in ascending order
+type Polynomial []*big.Int
+
+//	degree of the polynomial, -1 for the zero polynomial
+func (p Polynomial) Degree() int {
+	for i := len(p) - 1; i >= 0; i-- {
+		if p[i].Sign() != 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+//	evaluate polynomial at x
+func (p Polynomial) Eval(x *big.Int) *big.Int {
+	result := new(big.Int)
+	for i := len(p) - 1; i >= 0; i-- {
+		result.Mul(result, x)
+		result.Add(result, p[i])
+		result.Mod(result, order)
+	}
+	return result
+}
+
+//	p - q
+func (p Polynomial) Sub(q Polynomial) Polynomial {
+	n := len(p)
+	if len(q) > n {
+		n = len(q)
+	}
+	result := make(Polynomial, n)
+	for i := range result {
+		result[i] = new(big.Int)
+		if i < len(p) {
+			result[i].Add(result[i], p[i])
+		}
+		if i < len(q) {
+			result[i].Sub(result[i], q[i])
+		}
+		result[i].Mod(result[i], order)
+	}
+	return result
+}
+
+//	p * q
+func (p Polynomial) Mul(q Polynomial) Polynomial {
+	if len(p) == 0 || len(q) == 0 {
+		return Polynomial{}
+	}
+	result := make(Polynomial, len(p)+len(q)-1)
+	for i := range result {
+		result[i] = new(big.Int)
+	}
+	for i, a := range p {
+		for j, b := range q {
+			result[i+j].Add(result[i+j], new(big.Int).Mul(a, b))
+		}
+	}
+	for i := range result {
+		result[i].Mod(result[i], order)
+	}
+	return result
+}
+
+//	long division, returns quotient and remainder of p / q
+func (p Polynomial) Div(q Polynomial) (quotient Polynomial, remainder Polynomial) {
+	dq := q.Degree()
+	if dq < 0 {
+		panic("[KZG] division by zero polynomial")
+	}
+
+	remainder = make(Polynomial, len(p))
+	for i := range p {
+		remainder[i] = new(big.Int).Mod(p[i], order)
+	}
+	dp := remainder.Degree()
+	if dp < dq {
+		return Polynomial{}, remainder
+	}
+
+	quotient = make(Polynomial, dp-dq+1)
+	for i := range quotient {
+		quotient[i] = new(big.Int)
+	}
+	lead := new(big.Int).ModInverse(q[dq], order)
+	for i := dp; i >= dq; i-- {
+		coefficient := new(big.Int).Mul(remainder[i], lead)
+		coefficient.Mod(coefficient, order)
+		quotient[i-dq] = coefficient
+		for j := 0; j <= dq; j++ {
+			t := new(big.Int).Mul(coefficient, q[j])
+			remainder[i-dq+j].Sub(remainder[i-dq+j], t)
+			remainder[i-dq+j].Mod(remainder[i-dq+j], order)
+		}
+	}
+	return quotient, remainder
+}
+
+//	(x - z_0)(x - z_1)...(x - z_k)
+func ZeroPolynomial(points []*big.Int) Polynomial {
+	result := Polynomial{big.NewInt(1)}
+	for _, z := range points {
+		result = result.Mul(Polynomial{new(big.Int).Neg(z), big.NewInt(1)})
+	}
+	return result
+}
+
+//	Lagrange interpolation of the polynomial of degree < len(xs) through (xs[i], ys[i]).
+//	the zero polynomial Z of all points is built once and every basis polynomial
+//	Z(x) / (x - x_i) is one synthetic division, so the cost is O(n^2) field operations
+func Interpolate(xs []*big.Int, ys []*big.Int) (Polynomial, error) {
+	if len(xs) != len(ys) {
+		return nil, ErrPointCount
+	}
+	seen := make(map[string]bool, len(xs))
+	for _, x := range xs {
+		key := new(big.Int).Mod(x, order).String()
+		if seen[key] {
+			return nil, ErrDuplicatePoint
+		}
+		seen[key] = true
+	}
+
+	n := len(xs)
+	result := make(Polynomial, n)
+	for i := range result {
+		result[i] = new(big.Int)
+	}
+	if n == 0 {
+		return result, nil
+	}
+
+	z := ZeroPolynomial(xs)
+	basis := make(Polynomial, n)
+	for i := range basis {
+		basis[i] = new(big.Int)
+	}
+	t := new(big.Int)
+	for i, x := range xs {
+		//	basis = Z(x) / (x - x_i), its value at x_i is prod_{j != i} (x_i - x_j)
+		basis[n-1].Set(z[n])
+		for k := n - 1; k > 0; k-- {
+			basis[k-1].Mul(basis[k], x)
+			basis[k-1].Add(basis[k-1], z[k])
+			basis[k-1].Mod(basis[k-1], order)
+		}
+		scale := new(big.Int).ModInverse(basis.Eval(x), order)
+		scale.Mul(scale, ys[i])
+		scale.Mod(scale, order)
+		for k := range basis {
+			t.Mul(basis[k], scale)
+			result[k].Add(result[k], t)
+			result[k].Mod(result[k], order)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,93 @@
+package kzg
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestPolynomialDiv(t *testing.T) {
+	fmt.Println("Test : polynomial division ...")
+
+	p := randomPolynomial(7)
+	q := Polynomial{big.NewInt(3), big.NewInt(0), big.NewInt(2)}
+
+	quotient, remainder := p.Div(q)
+	if remainder.Degree() >= q.Degree() {
+		t.Fatalf("remainder degree %d is not below %d\n", remainder.Degree(), q.Degree())
+	}
+
+	// p == quotient * q + remainder
+	diff := p.Sub(quotient.Mul(q)).Sub(remainder)
+	if diff.Degree() != -1 {
+		t.Fatalf("division does not reconstruct the dividend\n")
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	fmt.Println("Test : polynomial interpolation ...")
+
+	p := randomPolynomial(5)
+	xs := []*big.Int{big.NewInt(2), big.NewInt(3), big.NewInt(5), big.NewInt(7), big.NewInt(11)}
+	ys := make([]*big.Int, len(xs))
+	for i, x := range xs {
+		ys[i] = p.Eval(x)
+	}
+
+	result, err := Interpolate(xs, ys)
+	if err != nil {
+		t.Fatalf("interpolate failed, %v\n", err)
+	}
+	if p.Sub(result).Degree() != -1 {
+		t.Fatalf("interpolation does not give back the polynomial\n")
+	}
+
+	if _, err := Interpolate([]*big.Int{big.NewInt(1), big.NewInt(1)}, ys[:2]); err != ErrDuplicatePoint {
+		t.Fatalf("got error %v but expected %v\n", err, ErrDuplicatePoint)
+	}
+	for _, values := range [][]*big.Int{ys[:4], append(ys, big.NewInt(1))} {
+		if _, err := Interpolate(xs, values); err != ErrPointCount {
+			t.Fatalf("got error %v but expected %v\n", err, ErrPointCount)
+		}
+	}
+}
+
+func TestInterpolateLarge(t *testing.T) {
+	fmt.Println("Test : polynomial interpolation of 1024 points ...")
+
+	t0 := time.Now()
+
+	xs := make([]*big.Int, 1024)
+	ys := make([]*big.Int, len(xs))
+	for i := range xs {
+		xs[i] = big.NewInt(int64(i))
+		ys[i] = new(big.Int).Lsh(big.NewInt(int64(i)), 200)
+	}
+	result, err := Interpolate(xs, ys)
+	if err != nil {
+		t.Fatalf("interpolate failed, %v\n", err)
+	}
+	for _, i := range []int{0, 1, 511, 1023} {
+		if result.Eval(xs[i]).Cmp(ys[i]) != 0 {
+			t.Fatalf("got result %v but expected %v\n", result.Eval(xs[i]), ys[i])
+		}
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func TestZeroPolynomial(t *testing.T) {
+	fmt.Println("Test : zero polynomial ...")
+
+	points := []*big.Int{big.NewInt(4), big.NewInt(8), big.NewInt(15)}
+	z := ZeroPolynomial(points)
+	for _, x := range points {
+		if z.Eval(x).Sign() != 0 {
+			t.Fatalf("zero polynomial does not vanish at %v\n", x)
+		}
+	}
+	if z.Eval(big.NewInt(16)).Sign() == 0 {
+		t.Fatalf("zero polynomial vanishes at 16\n")
+	}
+}
@@ -0,0 +1,166 @@
+package kzg
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+	"log"
+	"math/big"
+
+	bls12 "github.com/phoreproject/bls"
+)
+
+var ErrSetup = errors.New("[KZG] malformed setup")
+
+//	powers of tau: [tau^i]G1 for i < len(G1) and [tau^i]G2 for i < len(G2)
+type Setup struct {
+	G1 []*bls12.G1Projective
+	G2 []*bls12.G2Projective
+}
+
+//	generate setup with a random tau that is thrown away.
+//	only for tests, the party running it can forge openings; load a ceremony output in production
+func GenerateSetup(g1Size int, g2Size int) *Setup {
+	tau, err := rand.Int(rand.Reader, order)
+	if err != nil {
+		log.Fatalf("[KZG] generate tau failed, %v\n", err)
+	}
+	return NewSetup(tau, g1Size, g2Size)
+}
+
+//	compute setup from a known tau
+func NewSetup(tau *big.Int, g1Size int, g2Size int) *Setup {
+	setup := &Setup{
+		G1: make([]*bls12.G1Projective, g1Size),
+		G2: make([]*bls12.G2Projective, g2Size),
+	}
+
+	power := big.NewInt(1)
+	for i := 0; i < g1Size || i < g2Size; i++ {
+		repr := scalarToRepr(power)
+		if i < g1Size {
+			setup.G1[i] = bls12.G1AffineOne.MulFR(repr)
+		}
+		if i < g2Size {
+			setup.G2[i] = bls12.G2AffineOne.MulFR(repr)
+		}
+		power.Mul(power, tau)
+		power.Mod(power, order)
+	}
+	return setup
+}
+
+//	maximum degree of a committed polynomial plus one
+func (s *Setup) Size() int {
+	return len(s.G1)
+}
+
+//	write setup as: len(G1) | len(G2) as uint32 big-endian, then the compressed points
+func (s *Setup) Serialize(w io.Writer) error {
+	var header [8]byte
+	binary.BigEndian.PutUint32(header[:4], uint32(len(s.G1)))
+	binary.BigEndian.PutUint32(header[4:], uint32(len(s.G2)))
+	if _, err := w.Write(header[:]); err != nil {
+		return err
+	}
+	for _, p := range s.G1 {
+		b := bls12.CompressG1(p.ToAffine())
+		if _, err := w.Write(b[:]); err != nil {
+			return err
+		}
+	}
+	for _, p := range s.G2 {
+		b := bls12.CompressG2(p.ToAffine())
+		if _, err := w.Write(b[:]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//	read setup written by Serialize, every point is checked to be in the subgroup
+func LoadSetup(r io.Reader) (*Setup, error) {
+	var header [8]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, ErrSetup
+	}
+	g1Size, g2Size := binary.BigEndian.Uint32(header[:4]), binary.BigEndian.Uint32(header[4:])
+	if g1Size < 2 || g2Size < 2 {
+		return nil, ErrSetup
+	}
+
+	setup := &Setup{}
+	for i := uint32(0); i < g1Size; i++ {
+		var b [48]byte
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			return nil, ErrSetup
+		}
+		p, err := bls12.DecompressG1(b)
+		if err != nil {
+			return nil, ErrSetup
+		}
+		setup.G1 = append(setup.G1, p.ToProjective())
+	}
+	for i := uint32(0); i < g2Size; i++ {
+		var b [96]byte
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			return nil, ErrSetup
+		}
+		p, err := bls12.DecompressG2(b)
+		if err != nil {
+			return nil, ErrSetup
+		}
+		setup.G2 = append(setup.G2, p.ToProjective())
+	}
+
+	//	both lists must start at the generators and every power must be the one before times tau
+	if !setup.G1[0].Equal(bls12.G1ProjectiveOne) || !setup.G2[0].Equals(bls12.G2ProjectiveOne) {
+		return nil, ErrSetup
+	}
+	if !setup.consistentPowers() {
+		return nil, ErrSetup
+	}
+	return setup, nil
+}
+
+//	with random r_i and s_j of 128 bits,
+//	e(sum r_i G1[i+1], G2[0]) == e(sum r_i G1[i], G2[1]) and
+//	e(G1[0], sum s_j G2[j+1]) == e(G1[1], sum s_j G2[j]).
+//	the first check ties G1[1] to G2[1], so both lists are powers of the same tau
+func (s *Setup) consistentPowers() bool {
+	lhs1, rhs1 := bls12.G1ProjectiveZero.Copy(), bls12.G1ProjectiveZero.Copy()
+	for i := 0; i+1 < len(s.G1); i++ {
+		r := scalarToRepr(randomChallenge())
+		lhs1 = lhs1.Add(s.G1[i+1].MulFR(r))
+		rhs1 = rhs1.Add(s.G1[i].MulFR(r))
+	}
+	if !pairingEqual(lhs1, s.G2[0], rhs1, s.G2[1]) {
+		return false
+	}
+
+	lhs2, rhs2 := bls12.G2ProjectiveZero.Copy(), bls12.G2ProjectiveZero.Copy()
+	for j := 0; j+1 < len(s.G2); j++ {
+		r := scalarToRepr(randomChallenge())
+		lhs2 = lhs2.Add(s.G2[j+1].MulFR(r))
+		rhs2 = rhs2.Add(s.G2[j].MulFR(r))
+	}
+	return pairingEqual(s.G1[0], lhs2, s.G1[1], rhs2)
+}
+
+//	uniform integer of 128 bits
+func randomChallenge() *big.Int {
+	r, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		log.Fatalf("[KZG] generate challenge failed, %v\n", err)
+	}
+	return r
+}
+
+func scalarToRepr(x *big.Int) *bls12.FRRepr {
+	repr, err := bls12.FRReprFromBigInt(new(big.Int).Mod(x, order))
+	if err != nil {
+		log.Fatalf("[KZG] convert scalar failed, %v\n", err)
+	}
+	return repr
+}
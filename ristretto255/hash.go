@@ -0,0 +1,29 @@
+package ristretto255
+
+import (
+	"crypto/sha512"
+
+	"go-cryptology/internal/h2c"
+)
+
+//	RFC 9380 suite of HashToElement
+const HashToGroupSuite = "ristretto255_XMD:SHA-512_R255MAP_RO_"
+
+//	RFC 9380 hash_to_ristretto255, 64 bytes of expand_message_xmd with SHA-512
+//	under the domain separation tag dst mapped by SetUniformBytes
+func HashToElement(message []byte, dst []byte) *Element {
+	e, _ := new(Element).SetUniformBytes(expandMessageXMD(message, dst))
+	return e
+}
+
+//	scalar of 64 bytes of expand_message_xmd with SHA-512, as hash_to_field
+func HashToScalar(message []byte, dst []byte) *Scalar {
+	s, _ := new(Scalar).SetUniformBytes(expandMessageXMD(message, dst))
+	return s
+}
+
+//	64 bytes of expand_message_xmd with SHA-512, below the bound of 255 * 64
+func expandMessageXMD(message []byte, dst []byte) []byte {
+	uniform, _ := h2c.ExpandMessageXMD(sha512.New, message, dst, 64)
+	return uniform
+}
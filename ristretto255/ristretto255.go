@@ -0,0 +1,297 @@
+package ristretto255
+
+import (
+	"crypto/subtle"
+	"errors"
+	"math/big"
+
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+)
+
+var (
+	ErrInvalidEncoding = errors.New("[RISTRETTO255] invalid element encoding")
+	ErrInputSize       = errors.New("[RISTRETTO255] uniform bytes must be 64 bytes")
+)
+
+var (
+	feOne = new(field.Element).One()
+	//	edwards25519 d
+	feD = fieldElement("37095705934669439343138083508754565189542113879843219016388785533085940283555")
+	//	sqrt(-1)
+	sqrtM1 = fieldElement("19681161376707505956807079304988542015446066515923890162744021073123829784752")
+	//	sqrt(a*d - 1)
+	sqrtADMinusOne = fieldElement("25063068953384623474111414158702152701244531502492656460079210482610430750235")
+	//	1 / sqrt(a - d)
+	invSqrtAMinusD = fieldElement("54469307008909316920995813868745141605393597292927456921205312896311721017578")
+	//	1 - d^2
+	oneMinusDSq = fieldElement("1159843021668779879193775521855586647937357759715417654439879720876111806838")
+	//	(d - 1)^2
+	dMinusOneSq = fieldElement("40440834346308536858101042469323190826248399146238708352240133220865137265952")
+)
+
+//	element of the prime order group, an equivalence class of edwards25519 points
+//	that differ by a point of order 4. the zero value is not valid, use NewElement
+type Element struct {
+	p edwards25519.Point
+}
+
+//	the identity element
+func NewElement() *Element {
+	e := new(Element)
+	e.p.Set(edwards25519.NewIdentityPoint())
+	return e
+}
+
+//	the canonical generator, the ed25519 base point
+func NewGeneratorElement() *Element {
+	e := new(Element)
+	e.p.Set(edwards25519.NewGeneratorPoint())
+	return e
+}
+
+func (e *Element) Set(x *Element) *Element {
+	e.p.Set(&x.p)
+	return e
+}
+
+func (e *Element) Add(p, q *Element) *Element {
+	e.p.Add(&p.p, &q.p)
+	return e
+}
+
+func (e *Element) Subtract(p, q *Element) *Element {
+	e.p.Subtract(&p.p, &q.p)
+	return e
+}
+
+func (e *Element) Negate(p *Element) *Element {
+	e.p.Negate(&p.p)
+	return e
+}
+
+//	e = s * p in constant time
+func (e *Element) ScalarMult(s *Scalar, p *Element) *Element {
+	e.p.ScalarMult(&s.s, &p.p)
+	return e
+}
+
+//	e = s * G in constant time
+func (e *Element) ScalarBaseMult(s *Scalar) *Element {
+	e.p.ScalarBaseMult(&s.s)
+	return e
+}
+
+//	e = sum(scalars[i] * elements[i]) in constant time
+func (e *Element) MultiScalarMult(scalars []*Scalar, elements []*Element) *Element {
+	s, p := unwrap(scalars, elements)
+	e.p.MultiScalarMult(s, p)
+	return e
+}
+
+//	e = sum(scalars[i] * elements[i]) in variable time, for public inputs only
+func (e *Element) VarTimeMultiScalarMult(scalars []*Scalar, elements []*Element) *Element {
+	s, p := unwrap(scalars, elements)
+	e.p.VarTimeMultiScalarMult(s, p)
+	return e
+}
+
+//	e = a * A + b * G in variable time, for public inputs only
+func (e *Element) VarTimeDoubleScalarBaseMult(a *Scalar, A *Element, b *Scalar) *Element {
+	e.p.VarTimeDoubleScalarBaseMult(&a.s, &A.p, &b.s)
+	return e
+}
+
+//	1 if e and u are the same element, 0 otherwise, in constant time:
+//	x1 * y2 == y1 * x2 or y1 * y2 == x1 * x2
+func (e *Element) Equal(u *Element) int {
+	X1, Y1, _, _ := e.p.ExtendedCoordinates()
+	X2, Y2, _, _ := u.p.ExtendedCoordinates()
+
+	var f0, f1 field.Element
+	f0.Multiply(X1, Y2)
+	f1.Multiply(Y1, X2)
+	out := f0.Equal(&f1)
+	f0.Multiply(Y1, Y2)
+	f1.Multiply(X1, X2)
+	return out | f0.Equal(&f1)
+}
+
+//	e = a if cond == 1, e = b if cond == 0, in constant time
+func (e *Element) Select(a, b *Element, cond int) *Element {
+	aX, aY, aZ, aT := a.p.ExtendedCoordinates()
+	bX, bY, bZ, bT := b.p.ExtendedCoordinates()
+
+	var X, Y, Z, T field.Element
+	X.Select(aX, bX, cond)
+	Y.Select(aY, bY, cond)
+	Z.Select(aZ, bZ, cond)
+	T.Select(aT, bT, cond)
+	e.p.SetExtendedCoordinates(&X, &Y, &Z, &T)
+	return e
+}
+
+//	RFC 9496 encoding, the same for every point of the class
+func (e *Element) Bytes() []byte {
+	X, Y, Z, T := e.p.ExtendedCoordinates()
+
+	// u1 = (Z + Y) * (Z - Y), u2 = X * Y
+	u1 := new(field.Element).Add(Z, Y)
+	u1.Multiply(u1, new(field.Element).Subtract(Z, Y))
+	u2 := new(field.Element).Multiply(X, Y)
+
+	// invsqrt = 1 / sqrt(u1 * u2^2)
+	tmp := new(field.Element).Square(u2)
+	tmp.Multiply(tmp, u1)
+	invSqrt, _ := new(field.Element).SqrtRatio(feOne, tmp)
+
+	den1 := new(field.Element).Multiply(invSqrt, u1)
+	den2 := new(field.Element).Multiply(invSqrt, u2)
+	zInv := new(field.Element).Multiply(den1, den2)
+	zInv.Multiply(zInv, T)
+
+	ix0 := new(field.Element).Multiply(X, sqrtM1)
+	iy0 := new(field.Element).Multiply(Y, sqrtM1)
+	enchantedDenominator := new(field.Element).Multiply(den1, invSqrtAMinusD)
+
+	rotate := tmp.Multiply(T, zInv).IsNegative()
+	x := new(field.Element).Select(iy0, X, rotate)
+	y := new(field.Element).Select(ix0, Y, rotate)
+	denInv := new(field.Element).Select(enchantedDenominator, den2, rotate)
+
+	negY := tmp.Multiply(x, zInv).IsNegative()
+	y.Select(new(field.Element).Negate(y), y, negY)
+
+	// s = |den_inv * (Z - y)|
+	s := new(field.Element).Subtract(Z, y)
+	s.Multiply(s, denInv)
+	return s.Absolute(s).Bytes()
+}
+
+//	RFC 9496 decoding, only canonical encodings of non-negative s are accepted
+func (e *Element) SetCanonicalBytes(b []byte) (*Element, error) {
+	if len(b) != 32 {
+		return nil, ErrInvalidEncoding
+	}
+	s, err := new(field.Element).SetBytes(b)
+	if err != nil {
+		return nil, ErrInvalidEncoding
+	}
+	if subtle.ConstantTimeCompare(s.Bytes(), b) != 1 || s.IsNegative() == 1 {
+		return nil, ErrInvalidEncoding
+	}
+
+	ss := new(field.Element).Square(s)
+	u1 := new(field.Element).Subtract(feOne, ss)
+	u2 := new(field.Element).Add(feOne, ss)
+	u2Sq := new(field.Element).Square(u2)
+
+	// v = -(d * u1^2) - u2^2
+	v := new(field.Element).Square(u1)
+	v.Multiply(v, feD)
+	v.Negate(v)
+	v.Subtract(v, u2Sq)
+
+	tmp := new(field.Element).Multiply(v, u2Sq)
+	invSqrt, wasSquare := new(field.Element).SqrtRatio(feOne, tmp)
+
+	denX := new(field.Element).Multiply(invSqrt, u2)
+	denY := new(field.Element).Multiply(invSqrt, denX)
+	denY.Multiply(denY, v)
+
+	// x = |2 * s * den_x|, y = u1 * den_y, t = x * y
+	x := new(field.Element).Add(s, s)
+	x.Multiply(x, denX)
+	x.Absolute(x)
+	y := new(field.Element).Multiply(u1, denY)
+	t := new(field.Element).Multiply(x, y)
+
+	if wasSquare == 0 || t.IsNegative() == 1 || y.Equal(new(field.Element).Zero()) == 1 {
+		return nil, ErrInvalidEncoding
+	}
+	if _, err := e.p.SetExtendedCoordinates(x, y, new(field.Element).One(), t); err != nil {
+		return nil, ErrInvalidEncoding
+	}
+	return e, nil
+}
+
+//	RFC 9496 element derivation from 64 uniform bytes, the sum of the maps of both halves
+func (e *Element) SetUniformBytes(b []byte) (*Element, error) {
+	if len(b) != 64 {
+		return nil, ErrInputSize
+	}
+	p := mapToPoint(b[:32])
+	e.p.Add(p, mapToPoint(b[32:]))
+	return e, nil
+}
+
+//	RFC 9496 MAP, the top bit of the input is ignored
+func mapToPoint(b []byte) *edwards25519.Point {
+	t, _ := new(field.Element).SetBytes(b)
+
+	// r = sqrt(-1) * t^2
+	r := new(field.Element).Square(t)
+	r.Multiply(r, sqrtM1)
+
+	// u = (r + 1) * (1 - d^2), v = (-1 - r * d) * (r + d)
+	u := new(field.Element).Add(r, feOne)
+	u.Multiply(u, oneMinusDSq)
+	rD := new(field.Element).Multiply(r, feD)
+	v := new(field.Element).Negate(feOne)
+	v.Subtract(v, rD)
+	v.Multiply(v, new(field.Element).Add(r, feD))
+
+	s, wasSquare := new(field.Element).SqrtRatio(u, v)
+	sPrime := new(field.Element).Multiply(s, t)
+	sPrime.Absolute(sPrime)
+	sPrime.Negate(sPrime)
+	s.Select(s, sPrime, wasSquare)
+	c := new(field.Element).Select(new(field.Element).Negate(feOne), r, wasSquare)
+
+	// N = c * (r - 1) * (d - 1)^2 - v
+	N := new(field.Element).Subtract(r, feOne)
+	N.Multiply(N, c)
+	N.Multiply(N, dMinusOneSq)
+	N.Subtract(N, v)
+
+	sSq := new(field.Element).Square(s)
+	w0 := new(field.Element).Add(s, s)
+	w0.Multiply(w0, v)
+	w1 := new(field.Element).Multiply(N, sqrtADMinusOne)
+	w2 := new(field.Element).Subtract(feOne, sSq)
+	w3 := new(field.Element).Add(feOne, sSq)
+
+	X := new(field.Element).Multiply(w0, w3)
+	Y := new(field.Element).Multiply(w2, w1)
+	Z := new(field.Element).Multiply(w1, w3)
+	T := new(field.Element).Multiply(w0, w2)
+	p, err := new(edwards25519.Point).SetExtendedCoordinates(X, Y, Z, T)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+func unwrap(scalars []*Scalar, elements []*Element) ([]*edwards25519.Scalar, []*edwards25519.Point) {
+	s := make([]*edwards25519.Scalar, len(scalars))
+	for i := range scalars {
+		s[i] = &scalars[i].s
+	}
+	p := make([]*edwards25519.Point, len(elements))
+	for i := range elements {
+		p[i] = &elements[i].p
+	}
+	return s, p
+}
+
+//	field element of a decimal constant below 2^255
+func fieldElement(decimal string) *field.Element {
+	n, _ := new(big.Int).SetString(decimal, 10)
+	var buf [32]byte
+	b := n.Bytes()
+	for i := range b {
+		buf[i] = b[len(b)-1-i]
+	}
+	fe, _ := new(field.Element).SetBytes(buf[:])
+	return fe
+}
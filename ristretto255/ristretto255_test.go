@@ -0,0 +1,277 @@
+package ristretto255
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+
+	"filippo.io/edwards25519"
+)
+
+//	RFC 9496 appendix A.1, encodings of 0 * G to 15 * G
+var multiplesVectors = []string{
+	"0000000000000000000000000000000000000000000000000000000000000000",
+	"e2f2ae0a6abc4e71a884a961c500515f58e30b6aa582dd8db6a65945e08d2d76",
+	"6a493210f7499cd17fecb510ae0cea23a110e8d5b901f8acadd3095c73a3b919",
+	"94741f5d5d52755ece4f23f044ee27d5d1ea1e2bd196b462166b16152a9d0259",
+	"da80862773358b466ffadfe0b3293ab3d9fd53c5ea6c955358f568322daf6a57",
+	"e882b131016b52c1d3337080187cf768423efccbb517bb495ab812c4160ff44e",
+	"f64746d3c92b13050ed8d80236a7f0007c3b3f962f5ba793d19a601ebb1df403",
+	"44f53520926ec81fbd5a387845beb7df85a96a24ece18738bdcfa6a7822a176d",
+	"903293d8f2287ebe10e2374dc1a53e0bc887e592699f02d077d5263cdd55601c",
+	"02622ace8f7303a31cafc63f8fc48fdc16e1c8c8d234b2f0d6685282a9076031",
+	"20706fd788b2720a1ed2a5dad4952b01f413bcf0e7564de8cdc816689e2db95f",
+	"bce83f8ba5dd2fa572864c24ba1810f9522bc6004afe95877ac73241cafdab42",
+	"e4549ee16b9aa03099ca208c67adafcafa4c3f3e4e5303de6026e3ca8ff84460",
+	"aa52e000df2e16f55fb1032fc33bc42742dad6bd5a8fc0be0167436c5948501f",
+	"46376b80f409b29dc2b5f6f0c52591990896e5716f41477cd30085ab7f10301e",
+	"e0c418f7c8d9c4cdd7395b93ea124f3ad99021bb681dfc3302a9d99a2e53e64e",
+}
+
+//	RFC 9496 appendix A.2: non-canonical, negative, non-square x^2, negative xy and s = -1
+var badEncodings = []string{
+	"00ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+	"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+	"f3ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+	"edffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+	"0100000000000000000000000000000000000000000000000000000000000000",
+	"01ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+	"ed57ffd8c914fb201471d1c3d245ce3c746fcbe63a3679d51b6a516ebebe0e20",
+	"c34c4e1826e5d403b78e246e88aa051c36ccf0aafebffe137d148a2bf9104562",
+	"c940e5a4404157cfb1628b108db051a8d439e1a421394ec4ebccb9ec92a8ac78",
+	"47cfc5497c53dc8e61c91d17fd626ffb1c49e2bca94eed052281b510b1117a24",
+	"f1c6165d33367351b0da8f6e4511010c68174a03b6581212c71c0e1d026c3c72",
+	"87260f7a2f12495118360f02c26a470f450dadf34a413d21042b43b9d93e1309",
+	"26948d35ca62e643e26a83177332e6b6afeb9d08e4268b650f1f5bbd8d81d371",
+	"4eac077a713c57b4f4397629a4145982c661f48044dd3f96427d40b147d9742f",
+	"de6a7b00deadc788eb6b6c8d20c0ae96c2f2019078fa604fee5b87d6e989ad7b",
+	"bcab477be20861e01e4a0e295284146a510150d9817763caf1a6f4b422d67042",
+	"2a292df7e32cababbd9de088d1d1abec9fc0440f637ed2fba145094dc14bea08",
+	"f4a9e534fc0d216c44b218fa0c42d99635a0127ee2e53c712f70609649fdff22",
+	"8268436f8c4126196cf64b3c7ddbda90746a378625f9813dd9b8457077256731",
+	"2810e5cbc2cc4d4eece54f61c6f69758e289aa7ab440b3cbeaa21995c2f4232b",
+	"3eb858e78f5a7254d8c9731174a94f76755fd3941c0ac93735c07ba14579630e",
+	"a45fdc55c76448c049a1ab33f17023edfb2be3581e9c7aade8a6125215e04220",
+	"d483fe813c6ba647ebbfd3ec41adca1c6130c2beeee9d9bf065c8d151c5f396e",
+	"8a2e1d30050198c65a54483123960ccc38aef6848e1ec8f5f780e8523769ba32",
+	"32888462f8b486c68ad7dd9610be5192bbeaf3b443951ac1a8118419d9fa097b",
+	"227142501b9d4355ccba290404bde41575b037693cef1f438c47f8fbf35d1165",
+	"5c37cc491da847cfeb9281d407efc41e15144c876e0170b499a96a22ed31e01e",
+	"445425117cb8c90edcbc7c1cc0e74f747f2c1efa5630a967c64f287792a48a4b",
+	"ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+}
+
+//	RFC 9496 appendix A.3, elements of SHA-512 of the inputs
+var uniformInputs = []string{
+	"Ristretto is traditionally a short shot of espresso coffee",
+	"made with the normal amount of ground coffee but extracted with",
+	"about half the amount of water in the same amount of time",
+	"by using a finer grind.",
+	"This produces a concentrated shot of coffee per volume.",
+	"Just pulling a normal shot short will produce a weaker shot",
+	"and is not a Ristretto as some believe.",
+}
+
+var uniformElements = []string{
+	"3066f82a1a747d45120d1740f14358531a8f04bbffe6a819f86dfe50f44a0a46",
+	"f26e5b6f7d362d2d2a94c5d0e7602cb4773c95a2e5c31a64f133189fa76ed61b",
+	"006ccd2a9e6867e6a2c5cea83d3302cc9de128dd2a9a57dd8ee7b9d7ffe02826",
+	"f8f0c87cf237953c5890aec3998169005dae3eca1fbb04548c635953c817f92a",
+	"ae81e7dedf20a497e10c304a765c1767a42d6e06029758d2d7e8ef7cc4c41179",
+	"e2705652ff9f5e44d3e841bf1c251cf7dddb77d140870d1ab2ed64f1a9ce8628",
+	"80bd07262511cdde4863f8a7434cef696750681cb9510eea557088f76d9e5065",
+}
+
+func TestEncoding(t *testing.T) {
+	fmt.Println("Test : ristretto255 encoding ...")
+
+	t0 := time.Now()
+
+	multiple := NewElement()
+	for i, v := range multiplesVectors {
+		b, _ := hex.DecodeString(v)
+		e, err := new(Element).SetCanonicalBytes(b)
+		if err != nil {
+			t.Fatalf("%d: got result %v but expected %v\n", i, err, nil)
+		}
+		if !bytes.Equal(e.Bytes(), b) || !bytes.Equal(multiple.Bytes(), b) || e.Equal(multiple) != 1 {
+			t.Fatalf("%d: got result %x but expected %v\n", i, multiple.Bytes(), v)
+		}
+		multiple.Add(multiple, NewGeneratorElement())
+	}
+
+	for i, v := range badEncodings {
+		b, _ := hex.DecodeString(v)
+		if _, err := new(Element).SetCanonicalBytes(b); err != ErrInvalidEncoding {
+			t.Fatalf("%d: got result %v but expected %v\n", i, err, ErrInvalidEncoding)
+		}
+	}
+	if _, err := new(Element).SetCanonicalBytes(make([]byte, 31)); err != ErrInvalidEncoding {
+		t.Fatalf("got result %v but expected %v\n", err, ErrInvalidEncoding)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func TestUniformBytes(t *testing.T) {
+	fmt.Println("Test : ristretto255 element derivation ...")
+
+	t0 := time.Now()
+
+	for i, input := range uniformInputs {
+		digest := sha512.Sum512([]byte(input))
+		e, err := new(Element).SetUniformBytes(digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(e.Bytes()) != uniformElements[i] {
+			t.Fatalf("%d: got result %x but expected %v\n", i, e.Bytes(), uniformElements[i])
+		}
+	}
+	if _, err := new(Element).SetUniformBytes(make([]byte, 32)); err != ErrInputSize {
+		t.Fatalf("got result %v but expected %v\n", err, ErrInputSize)
+	}
+
+	//	hash to group is deterministic and separated by the tag
+	dst := []byte("go-cryptology-V01-CS01-with-" + HashToGroupSuite)
+	e1, e2 := HashToElement([]byte("abc"), dst), HashToElement([]byte("abc"), dst)
+	e3 := HashToElement([]byte("abc"), []byte("another tag"))
+	if e1.Equal(e2) != 1 || e1.Equal(e3) == 1 {
+		t.Fatalf("got result %x but expected %x\n", e3.Bytes(), e1.Bytes())
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+//	RFC 9497 appendix A.1.1, OPRF(ristretto255, SHA-512): the key derived by HashToScalar
+//	from the seed and the outputs SHA-512(input || [skS]HashToElement(input) || "Finalize")
+var oprfVector = struct {
+	seed, info, secretKey string
+	inputs, outputs       []string
+}{
+	"a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3a3",
+	"74657374206b6579",
+	"5ebcea5ee37023ccb9fc2d2019f9d7737be85591ae8652ffa9ef0f4d37063b0e",
+	[]string{"00", "5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a5a"},
+	[]string{
+		"527759c3d9366f277d8c6020418d96bb393ba2afb20ff90df23fb7708264e2f3ab9135e3bd69955851de4b1f9fe8a0973396719b7912ba9ee8aa7d0b5e24bcf6",
+		"f4a74c9c592497375e796aa837e907b1a045d34306a749db9f34221f7e750cb4f2a6413a6bf6fa5e19ba6348eb673934a722a7ede2e7621306d18951e7cf2c73",
+	},
+}
+
+func TestHashToGroup(t *testing.T) {
+	fmt.Println("Test : ristretto255 hash to group ...")
+
+	t0 := time.Now()
+
+	contextString := "OPRFV1-\x00-ristretto255-SHA512"
+
+	//	DeriveKeyPair, seed || I2OSP(len(info), 2) || info || counter with counter 0
+	seed, _ := hex.DecodeString(oprfVector.seed)
+	info, _ := hex.DecodeString(oprfVector.info)
+	deriveInput := append(append(seed, 0, byte(len(info))), info...)
+	secretKey := HashToScalar(append(deriveInput, 0), []byte("DeriveKeyPair"+contextString))
+	if hex.EncodeToString(secretKey.Bytes()) != oprfVector.secretKey {
+		t.Fatalf("got result %x but expected %v\n", secretKey.Bytes(), oprfVector.secretKey)
+	}
+
+	for i, input := range oprfVector.inputs {
+		message, _ := hex.DecodeString(input)
+		element := HashToElement(message, []byte("HashToGroup-"+contextString))
+		h := sha512.New()
+		h.Write([]byte{0, byte(len(message))})
+		h.Write(message)
+		h.Write([]byte{0, 32})
+		h.Write(new(Element).ScalarMult(secretKey, element).Bytes())
+		h.Write([]byte("Finalize"))
+		if hex.EncodeToString(h.Sum(nil)) != oprfVector.outputs[i] {
+			t.Fatalf("got result %x but expected %v\n", h.Sum(nil), oprfVector.outputs[i])
+		}
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func TestGroup(t *testing.T) {
+	fmt.Println("Test : ristretto255 group operations ...")
+
+	t0 := time.Now()
+
+	a, b := RandomScalar(), RandomScalar()
+	A := new(Element).ScalarBaseMult(a)
+	B := new(Element).ScalarMult(b, NewGeneratorElement())
+
+	//	(a + b)G = aG + bG, b(aG) = (ab)G, A - A = 0
+	sum := new(Element).Add(A, B)
+	if sum.Equal(new(Element).ScalarBaseMult(new(Scalar).Add(a, b))) != 1 {
+		t.Fatalf("got result %x but expected (a + b)G\n", sum.Bytes())
+	}
+	ab := new(Element).ScalarMult(b, A)
+	if ab.Equal(new(Element).ScalarBaseMult(new(Scalar).Multiply(a, b))) != 1 {
+		t.Fatalf("got result %x but expected (ab)G\n", ab.Bytes())
+	}
+	if new(Element).Subtract(A, A).Equal(NewElement()) != 1 || new(Element).Add(A, new(Element).Negate(A)).Equal(NewElement()) != 1 {
+		t.Fatalf("got result %v but expected %v\n", false, true)
+	}
+
+	//	multiscalar multiplications
+	c := RandomScalar()
+	wanted := new(Element).Add(new(Element).ScalarMult(c, A), new(Element).ScalarBaseMult(b))
+	msm := new(Element).MultiScalarMult([]*Scalar{c, b}, []*Element{A, NewGeneratorElement()})
+	vmsm := new(Element).VarTimeMultiScalarMult([]*Scalar{c, b}, []*Element{A, NewGeneratorElement()})
+	double := new(Element).VarTimeDoubleScalarBaseMult(c, A, b)
+	if msm.Equal(wanted) != 1 || vmsm.Equal(wanted) != 1 || double.Equal(wanted) != 1 {
+		t.Fatalf("got result %x but expected %x\n", msm.Bytes(), wanted.Bytes())
+	}
+
+	//	points that differ by a point of order 4 are the same element with the same encoding
+	order8, _ := hex.DecodeString("c7176a703d4dd84fba3c0b760d10670f2a2053fa2c39ccc64ec7fd7792ac037a")
+	T4, _ := new(edwards25519.Point).SetBytes(order8)
+	T4.Add(T4, T4)
+	if T4.Equal(edwards25519.NewIdentityPoint()) == 1 || new(edwards25519.Point).Add(T4, T4).Equal(edwards25519.NewIdentityPoint()) == 1 {
+		t.Fatalf("got result %x but expected a point of order 4\n", T4.Bytes())
+	}
+	shifted := new(Element)
+	shifted.p.Add(&A.p, T4)
+	if shifted.Equal(A) != 1 || !bytes.Equal(shifted.Bytes(), A.Bytes()) {
+		t.Fatalf("got result %x but expected %x\n", shifted.Bytes(), A.Bytes())
+	}
+
+	//	constant time selection
+	if new(Element).Select(A, B, 1).Equal(A) != 1 || new(Element).Select(A, B, 0).Equal(B) != 1 {
+		t.Fatalf("got result %v but expected %v\n", false, true)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func BenchmarkEncode(b *testing.B) {
+	e := new(Element).ScalarBaseMult(RandomScalar())
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		e.Bytes()
+	}
+}
+
+func BenchmarkDecode(b *testing.B) {
+	encoding := new(Element).ScalarBaseMult(RandomScalar()).Bytes()
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		new(Element).SetCanonicalBytes(encoding)
+	}
+}
+
+func BenchmarkScalarMult(b *testing.B) {
+	s := RandomScalar()
+	e := HashToElement([]byte("hello world"), []byte(HashToGroupSuite))
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		e.ScalarMult(s, e)
+	}
+}
@@ -0,0 +1,95 @@
+package ristretto255
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+	"log"
+
+	"filippo.io/edwards25519"
+)
+
+var ErrInvalidScalar = errors.New("[RISTRETTO255] invalid scalar encoding")
+
+//	integer modulo the group order l = 2^252 + 27742317777372353535851937790883648493
+type Scalar struct {
+	s edwards25519.Scalar
+}
+
+//	the scalar zero
+func NewScalar() *Scalar {
+	return new(Scalar)
+}
+
+//	uniform scalar using randomness from crypto/rand
+func RandomScalar() *Scalar {
+	var b [64]byte
+	if _, err := io.ReadFull(rand.Reader, b[:]); err != nil {
+		log.Fatal(err)
+	}
+	s, _ := new(Scalar).SetUniformBytes(b[:])
+	return s
+}
+
+func (s *Scalar) Set(x *Scalar) *Scalar {
+	s.s.Set(&x.s)
+	return s
+}
+
+func (s *Scalar) Add(x, y *Scalar) *Scalar {
+	s.s.Add(&x.s, &y.s)
+	return s
+}
+
+func (s *Scalar) Subtract(x, y *Scalar) *Scalar {
+	s.s.Subtract(&x.s, &y.s)
+	return s
+}
+
+func (s *Scalar) Negate(x *Scalar) *Scalar {
+	s.s.Negate(&x.s)
+	return s
+}
+
+func (s *Scalar) Multiply(x, y *Scalar) *Scalar {
+	s.s.Multiply(&x.s, &y.s)
+	return s
+}
+
+//	s = x * y + z
+func (s *Scalar) MultiplyAdd(x, y, z *Scalar) *Scalar {
+	s.s.MultiplyAdd(&x.s, &y.s, &z.s)
+	return s
+}
+
+//	s = 1 / x in constant time, the inverse of zero is zero
+func (s *Scalar) Invert(x *Scalar) *Scalar {
+	s.s.Invert(&x.s)
+	return s
+}
+
+//	1 if s and x are equal, 0 otherwise, in constant time
+func (s *Scalar) Equal(x *Scalar) int {
+	return s.s.Equal(&x.s)
+}
+
+//	32 bytes little-endian
+func (s *Scalar) Bytes() []byte {
+	return s.s.Bytes()
+}
+
+//	decode 32 bytes little-endian less than l
+func (s *Scalar) SetCanonicalBytes(b []byte) (*Scalar, error) {
+	if _, err := s.s.SetCanonicalBytes(b); err != nil {
+		return nil, ErrInvalidScalar
+	}
+	return s, nil
+}
+
+//	reduce 64 uniform bytes modulo l
+func (s *Scalar) SetUniformBytes(b []byte) (*Scalar, error) {
+	if _, err := s.s.SetUniformBytes(b); err != nil {
+		return nil, ErrInputSize
+	}
+	return s, nil
+}
@@ -0,0 +1,53 @@
+package ristretto255
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestScalar(t *testing.T) {
+	fmt.Println("Test : ristretto255 scalar ...")
+
+	t0 := time.Now()
+
+	one, _ := new(Scalar).SetCanonicalBytes(append([]byte{1}, make([]byte, 31)...))
+	a, b, c := RandomScalar(), RandomScalar(), RandomScalar()
+
+	if new(Scalar).Subtract(a, a).Equal(NewScalar()) != 1 || new(Scalar).Add(a, new(Scalar).Negate(a)).Equal(NewScalar()) != 1 {
+		t.Fatalf("got result %v but expected %v\n", false, true)
+	}
+	if new(Scalar).Multiply(a, new(Scalar).Invert(a)).Equal(one) != 1 {
+		t.Fatalf("got result %v but expected %v\n", false, true)
+	}
+	wanted := new(Scalar).Add(new(Scalar).Multiply(a, b), c)
+	if new(Scalar).MultiplyAdd(a, b, c).Equal(wanted) != 1 {
+		t.Fatalf("got result %x but expected %x\n", new(Scalar).MultiplyAdd(a, b, c).Bytes(), wanted.Bytes())
+	}
+
+	decoded, err := new(Scalar).SetCanonicalBytes(a.Bytes())
+	if err != nil || decoded.Equal(a) != 1 {
+		t.Fatalf("got result %v but expected %v\n", err, nil)
+	}
+
+	//	l is not canonical, l - 1 = -1 is
+	order, _ := hex.DecodeString("edd3f55c1a631258d69cf7a2def9de1400000000000000000000000000000010")
+	if _, err := new(Scalar).SetCanonicalBytes(order); err != ErrInvalidScalar {
+		t.Fatalf("got result %v but expected %v\n", err, ErrInvalidScalar)
+	}
+	order[0]--
+	minusOne, err := new(Scalar).SetCanonicalBytes(order)
+	if err != nil || minusOne.Equal(new(Scalar).Negate(one)) != 1 {
+		t.Fatalf("got result %v but expected %v\n", err, nil)
+	}
+
+	if _, err := new(Scalar).SetUniformBytes(make([]byte, 32)); err != ErrInputSize {
+		t.Fatalf("got result %v but expected %v\n", err, ErrInputSize)
+	}
+	if HashToScalar([]byte("abc"), []byte("tag")).Equal(HashToScalar([]byte("abd"), []byte("tag"))) == 1 {
+		t.Fatalf("got result %v but expected %v\n", true, false)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
@@ -9,7 +9,12 @@ import (
 
 //	generate RSA private key and public key
 func GenRSAKey() (priKey *rsa.PrivateKey, pubKey *rsa.PublicKey) {
-	priKey, err := rsa.GenerateKey(crand.Reader, 1024)
+	return GenRSAKeyBits(1024)
+}
+
+//	generate RSA private key and public key with a modulus of bits
+func GenRSAKeyBits(bits int) (priKey *rsa.PrivateKey, pubKey *rsa.PublicKey) {
+	priKey, err := rsa.GenerateKey(crand.Reader, bits)
 	if err != nil {
 		log.Fatalf("[RSA] generate rsa key failed, %v\n", err)
 	}
@@ -0,0 +1,291 @@
+package vdf
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math/big"
+)
+
+var ErrDiscriminant = errors.New("[VDF] discriminant must be -p for a prime p = 7 mod 8 of at least 512 bits")
+
+//	bits of the smallest discriminant, the same bound as the modulus of NewRSAGroup
+const MinDiscriminantBits = 512
+
+var (
+	bigOne   = big.NewInt(1)
+	bigTwo   = big.NewInt(2)
+	bigFour  = big.NewInt(4)
+	bigEight = big.NewInt(8)
+)
+
+//	class group of binary quadratic forms (a, b, c) of discriminant D = b^2 - 4ac < 0,
+//	the order is unknown and computing it for a large D is as hard as for a random D, so no trusted setup is needed
+type ClassGroup struct {
+	d *big.Int
+	//	bytes of a and |b| of a reduced form, |b| <= a <= sqrt(|D| / 3)
+	size int
+}
+
+//	binary quadratic form, always kept reduced
+type Form struct {
+	A, B, C *big.Int
+}
+
+//	group of the discriminant D = -p, p prime makes D fundamental and the class number odd,
+//	so there are no elements of order 2 and every form of discriminant D is primitive
+func NewClassGroup(discriminant *big.Int) (*ClassGroup, error) {
+	if discriminant.Sign() >= 0 || discriminant.BitLen() < MinDiscriminantBits ||
+		new(big.Int).Mod(discriminant, bigEight).Cmp(bigOne) != 0 ||
+		!new(big.Int).Neg(discriminant).ProbablyPrime(20) {
+		return nil, ErrDiscriminant
+	}
+	return &ClassGroup{
+		d:    new(big.Int).Set(discriminant),
+		size: (discriminant.BitLen()/2 + 8) / 8,
+	}, nil
+}
+
+//	D = -p with p = 7 mod 8 the first prime after a number of bits derived from seed,
+//	as in the Chia VDF, anyone can check that nobody knows the order of the group.
+//	bits must be at least MinDiscriminantBits
+func ClassGroupFromSeed(seed []byte, bits int) *ClassGroup {
+	var expanded []byte
+	var counter [4]byte
+	for i := uint32(0); len(expanded)*8 < bits; i++ {
+		binary.BigEndian.PutUint32(counter[:], i)
+		h := sha256.New()
+		h.Write([]byte("VDF_CLASS_GROUP_DISCRIMINANT_"))
+		h.Write(counter[:])
+		h.Write(seed)
+		expanded = h.Sum(expanded)
+	}
+	p := new(big.Int).SetBytes(expanded)
+	p.Rsh(p, uint(len(expanded)*8-bits))
+	p.SetBit(p, bits-1, 1)
+	// p = 7 mod 8
+	p.Sub(p, new(big.Int).Mod(p, bigEight))
+	p.Add(p, big.NewInt(7))
+	for !p.ProbablyPrime(20) {
+		p.Add(p, bigEight)
+	}
+	group, err := NewClassGroup(p.Neg(p))
+	if err != nil {
+		panic(err)
+	}
+	return group
+}
+
+//	discriminant of the group
+func (g *ClassGroup) Discriminant() *big.Int {
+	return new(big.Int).Set(g.d)
+}
+
+//	form (a, b, c) whose a is the first prime after H(challenge) with D a square mod a
+func (g *ClassGroup) HashToElement(challenge []byte) Element {
+	digest := sha256.Sum256(challenge)
+	a := new(big.Int).SetBytes(digest[:])
+	// a is well below sqrt(|D| / 4) so the form is close to reduced
+	if max := g.d.BitLen()/2 - 2; a.BitLen() > max {
+		a.Rsh(a, uint(a.BitLen()-max))
+	}
+	a.SetBit(a, 0, 1)
+	for {
+		a.Add(a, bigTwo)
+		if !a.ProbablyPrime(20) {
+			continue
+		}
+		dModA := new(big.Int).Mod(g.d, a)
+		b := new(big.Int).ModSqrt(dModA, a)
+		if b == nil {
+			continue
+		}
+		// b = D mod 2, then b^2 = D mod 4a
+		if b.Bit(0) == 0 {
+			b.Sub(a, b)
+		}
+		return g.newForm(a, b)
+	}
+}
+
+//	(1, 1, (1 - D) / 4)
+func (g *ClassGroup) Identity() Element {
+	return g.newForm(big.NewInt(1), big.NewInt(1))
+}
+
+//	(2, 1, (1 - D) / 8), the generator of the Chia VDF
+func (g *ClassGroup) Generator() Element {
+	return g.newForm(big.NewInt(2), big.NewInt(1))
+}
+
+//	composition of forms, Cohen algorithm 5.4.7 followed by reduction
+func (g *ClassGroup) Mul(x Element, y Element) Element {
+	f1, f2 := x.(*Form), y.(*Form)
+	a1, b1, c1 := f1.A, f1.B, f1.C
+	a2, b2 := f2.A, f2.B
+
+	// g = (b1 + b2) / 2, h = (b2 - b1) / 2, w = gcd(a1, a2, g)
+	gg := new(big.Int).Add(b1, b2)
+	gg.Rsh(gg, 1)
+	h := new(big.Int).Sub(b2, b1)
+	h.Rsh(h, 1)
+	w := new(big.Int).GCD(nil, nil, a1, a2)
+	w.GCD(nil, nil, w, new(big.Int).Abs(gg))
+
+	s := new(big.Int).Quo(a1, w)
+	t := new(big.Int).Quo(a2, w)
+	u := new(big.Int).Quo(gg, w)
+	st := new(big.Int).Mul(s, t)
+
+	// solve (tu)k = hu + s*c1 mod st, then t*k1*n = h - t*k0 mod s
+	tu := new(big.Int).Mul(t, u)
+	hu := new(big.Int).Mul(h, u)
+	huSc1 := new(big.Int).Add(hu, new(big.Int).Mul(s, c1))
+	k0, step := solveMod(tu, huSc1, st)
+	n, _ := solveMod(new(big.Int).Mul(t, step), new(big.Int).Sub(h, new(big.Int).Mul(t, k0)), s)
+	k := new(big.Int).Add(k0, new(big.Int).Mul(step, n))
+
+	// l = (tk - h) / s, m = (tuk - hu - s*c1) / st
+	l := new(big.Int).Mul(t, k)
+	l.Sub(l, h)
+	l.Div(l, s)
+	m := new(big.Int).Mul(tu, k)
+	m.Sub(m, huSc1)
+	m.Div(m, st)
+
+	// (st, wu - (kt + ls), kl - wm)
+	a3 := st
+	b3 := new(big.Int).Mul(w, u)
+	b3.Sub(b3, new(big.Int).Mul(k, t))
+	b3.Sub(b3, new(big.Int).Mul(l, s))
+	c3 := new(big.Int).Mul(k, l)
+	c3.Sub(c3, new(big.Int).Mul(w, m))
+	return reduce(&Form{A: a3, B: b3, C: c3})
+}
+
+func (g *ClassGroup) Square(x Element) Element {
+	return g.Mul(x, x)
+}
+
+//	(a, -b, c)
+func (g *ClassGroup) Inverse(x Element) Element {
+	f := x.(*Form)
+	return reduce(&Form{A: new(big.Int).Set(f.A), B: new(big.Int).Neg(f.B), C: new(big.Int).Set(f.C)})
+}
+
+func (g *ClassGroup) Equal(x Element, y Element) bool {
+	f1, f2 := x.(*Form), y.(*Form)
+	return f1.A.Cmp(f2.A) == 0 && f1.B.Cmp(f2.B) == 0
+}
+
+//	a || sign of b || |b|, c is recomputed from the discriminant
+func (g *ClassGroup) Encode(x Element) []byte {
+	f := x.(*Form)
+	b := make([]byte, 2*g.size+1)
+	f.A.FillBytes(b[:g.size])
+	if f.B.Sign() < 0 {
+		b[g.size] = 1
+	}
+	new(big.Int).Abs(f.B).FillBytes(b[g.size+1:])
+	return b
+}
+
+//	form must be primitive, reduced and of the discriminant of the group
+func (g *ClassGroup) Decode(b []byte) (Element, error) {
+	if len(b) != 2*g.size+1 || b[g.size] > 1 {
+		return nil, ErrInvalidElement
+	}
+	a := new(big.Int).SetBytes(b[:g.size])
+	bb := new(big.Int).SetBytes(b[g.size+1:])
+	if b[g.size] == 1 {
+		bb.Neg(bb)
+	}
+	if a.Sign() <= 0 {
+		return nil, ErrInvalidElement
+	}
+
+	// c = (b^2 - D) / 4a must be exact
+	c := new(big.Int).Mul(bb, bb)
+	c.Sub(c, g.d)
+	fourA := new(big.Int).Mul(a, bigFour)
+	c, r := c.QuoRem(c, fourA, new(big.Int))
+	if r.Sign() != 0 {
+		return nil, ErrInvalidElement
+	}
+	f := &Form{A: a, B: bb, C: c}
+	if !isReduced(f) || !isPrimitive(f) {
+		return nil, ErrInvalidElement
+	}
+	return f, nil
+}
+
+//	(a, b, (b^2 - D) / 4a) reduced
+func (g *ClassGroup) newForm(a *big.Int, b *big.Int) *Form {
+	c := new(big.Int).Mul(b, b)
+	c.Sub(c, g.d)
+	c.Quo(c, new(big.Int).Mul(a, bigFour))
+	return reduce(&Form{A: new(big.Int).Set(a), B: new(big.Int).Set(b), C: c})
+}
+
+//	-a < b <= a
+func normalize(f *Form) *Form {
+	negA := new(big.Int).Neg(f.A)
+	if negA.Cmp(f.B) < 0 && f.B.Cmp(f.A) <= 0 {
+		return f
+	}
+	// r = floor((a - b) / 2a), b' = b + 2ra, c' = ar^2 + br + c
+	r := new(big.Int).Sub(f.A, f.B)
+	r.Div(r, new(big.Int).Lsh(f.A, 1))
+	c := new(big.Int).Mul(f.A, r)
+	c.Add(c, f.B)
+	c.Mul(c, r)
+	c.Add(c, f.C)
+	b := new(big.Int).Mul(r, f.A)
+	b.Lsh(b, 1)
+	b.Add(b, f.B)
+	return &Form{A: f.A, B: b, C: c}
+}
+
+//	|b| <= a <= c, and b >= 0 if a = |b| or a = c
+func reduce(f *Form) *Form {
+	f = normalize(f)
+	for f.A.Cmp(f.C) > 0 || (f.A.Cmp(f.C) == 0 && f.B.Sign() < 0) {
+		// s = floor((c + b) / 2c), (a, b, c) = (c, -b + 2sc, cs^2 - bs + a)
+		s := new(big.Int).Add(f.C, f.B)
+		s.Div(s, new(big.Int).Lsh(f.C, 1))
+		b := new(big.Int).Mul(s, f.C)
+		b.Lsh(b, 1)
+		b.Sub(b, f.B)
+		c := new(big.Int).Mul(f.C, s)
+		c.Sub(c, f.B)
+		c.Mul(c, s)
+		c.Add(c, f.A)
+		f = &Form{A: f.C, B: b, C: c}
+	}
+	return normalize(f)
+}
+
+func isReduced(f *Form) bool {
+	negA := new(big.Int).Neg(f.A)
+	if !(negA.Cmp(f.B) < 0 && f.B.Cmp(f.A) <= 0) {
+		return false
+	}
+	cmp := f.A.Cmp(f.C)
+	return cmp < 0 || (cmp == 0 && f.B.Sign() >= 0)
+}
+
+//	gcd(a, b, c) = 1
+func isPrimitive(f *Form) bool {
+	gcd := new(big.Int).GCD(nil, nil, f.A, new(big.Int).Abs(f.B))
+	return gcd.GCD(nil, nil, gcd, f.C).Cmp(bigOne) == 0
+}
+
+//	x = x0 + k * step for all k solves a*x = b mod m, the equation must be solvable
+func solveMod(a *big.Int, b *big.Int, m *big.Int) (x0 *big.Int, step *big.Int) {
+	d, e := new(big.Int), new(big.Int)
+	g := new(big.Int).GCD(d, e, new(big.Int).Mod(a, m), m)
+	q := new(big.Int).Div(b, g)
+	x0 = q.Mul(q, d)
+	x0.Mod(x0, m)
+	return x0, new(big.Int).Div(m, g)
+}
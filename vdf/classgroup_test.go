@@ -0,0 +1,105 @@
+package vdf
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestClassGroup(t *testing.T) {
+	fmt.Println("Test : VDF class group ...")
+
+	t0 := time.Now()
+
+	g := testClassGroup
+	d := g.Discriminant()
+	x := g.HashToElement([]byte("hello world"))
+	y := g.HashToElement([]byte("hello VDF"))
+	z := g.Generator()
+
+	//	forms stay reduced and keep the discriminant
+	for _, e := range []Element{x, y, z, g.Mul(x, y), g.Square(z), Exp(g, x, big.NewInt(12345))} {
+		f := e.(*Form)
+		disc := new(big.Int).Mul(f.B, f.B)
+		disc.Sub(disc, new(big.Int).Mul(bigFour, new(big.Int).Mul(f.A, f.C)))
+		if disc.Cmp(d) != 0 || !isReduced(f) {
+			t.Fatalf("got form %v but expected a reduced form of discriminant %v\n", f, d)
+		}
+	}
+
+	//	group laws
+	if !g.Equal(g.Mul(g.Mul(x, y), z), g.Mul(x, g.Mul(y, z))) {
+		t.Fatalf("composition is not associative\n")
+	}
+	if !g.Equal(g.Mul(x, y), g.Mul(y, x)) {
+		t.Fatalf("composition is not commutative\n")
+	}
+	if !g.Equal(g.Mul(x, g.Identity()), x) || !g.Equal(g.Mul(x, g.Inverse(x)), g.Identity()) {
+		t.Fatalf("identity or inverse is wrong\n")
+	}
+	a, b := big.NewInt(1000003), big.NewInt(999983)
+	if !g.Equal(g.Mul(Exp(g, x, a), Exp(g, x, b)), Exp(g, x, new(big.Int).Add(a, b))) {
+		t.Fatalf("x^a * x^b differs from x^(a+b)\n")
+	}
+	if !g.Equal(repeatedSquare(g, x, 10), Exp(g, x, big.NewInt(1024))) {
+		t.Fatalf("x^(2^10) differs from x^1024\n")
+	}
+
+	//	encoding
+	decoded, err := g.Decode(g.Encode(x))
+	if err != nil || !g.Equal(decoded, x) {
+		t.Fatalf("got result %v but expected %v\n", decoded, x)
+	}
+	unreduced := g.Encode(x)
+	unreduced[len(unreduced)-1] ^= 2
+	if _, err := g.Decode(unreduced); err != ErrInvalidElement {
+		t.Fatalf("got result %v but expected %v\n", err, ErrInvalidElement)
+	}
+
+	//	not 1 mod 8, too small, not prime or not fundamental
+	nonFundamental := new(big.Int).Mul(d, big.NewInt(9))
+	composite := new(big.Int).Mul(d, big.NewInt(17))
+	for _, D := range []*big.Int{big.NewInt(-5), big.NewInt(-7), new(big.Int).Sub(d, bigEight), nonFundamental, composite} {
+		if _, err := NewClassGroup(D); err != ErrDiscriminant {
+			t.Fatalf("got result %v but expected %v\n", err, ErrDiscriminant)
+		}
+	}
+	if _, err := NewClassGroup(d); err != nil {
+		t.Fatalf("got result %v but expected %v\n", err, nil)
+	}
+
+	//	(3a, 3b, 3c) is a reduced form of discriminant 9D but not primitive
+	f := x.(*Form)
+	scaled := &ClassGroup{d: nonFundamental, size: (nonFundamental.BitLen()/2 + 8) / 8}
+	nonPrimitive := &Form{A: new(big.Int).Mul(f.A, big.NewInt(3)), B: new(big.Int).Mul(f.B, big.NewInt(3)), C: new(big.Int).Mul(f.C, big.NewInt(3))}
+	if _, err := scaled.Decode(scaled.Encode(nonPrimitive)); err != ErrInvalidElement {
+		t.Fatalf("got result %v but expected %v\n", err, ErrInvalidElement)
+	}
+	primitive := scaled.newForm(f.A, new(big.Int).Mul(f.B, big.NewInt(3)))
+	if _, err := scaled.Decode(scaled.Encode(primitive)); err != nil {
+		t.Fatalf("got result %v but expected %v\n", err, nil)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func BenchmarkClassGroupSquare(b *testing.B) {
+	g := ClassGroupFromSeed([]byte("go-cryptology vdf benchmark"), 1024)
+	x := g.Generator()
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		x = g.Square(x)
+	}
+}
+
+func BenchmarkRSAGroupSquare(b *testing.B) {
+	g := GenRSAGroup(2048)
+	x := g.HashToElement([]byte("hello world"))
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		x = g.Square(x)
+	}
+}
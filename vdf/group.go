@@ -0,0 +1,44 @@
+package vdf
+
+import (
+	"errors"
+	"math/big"
+)
+
+var ErrInvalidElement = errors.New("[VDF] invalid group element")
+
+//	element of a group of unknown order, it is only meaningful to the group that created it
+type Element interface{}
+
+//	group of unknown order the delay is evaluated in
+type Group interface {
+	//	deterministic element of the challenge
+	HashToElement(challenge []byte) Element
+	Identity() Element
+	Mul(x Element, y Element) Element
+	Square(x Element) Element
+	Equal(x Element, y Element) bool
+	//	fixed-size encoding
+	Encode(x Element) []byte
+	Decode(b []byte) (Element, error)
+}
+
+//	x^e by left-to-right square-and-multiply, e must not be negative
+func Exp(group Group, x Element, e *big.Int) Element {
+	result := group.Identity()
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		result = group.Square(result)
+		if e.Bit(i) == 1 {
+			result = group.Mul(result, x)
+		}
+	}
+	return result
+}
+
+//	x^(2^t) by t sequential squarings
+func repeatedSquare(group Group, x Element, t uint64) Element {
+	for i := uint64(0); i < t; i++ {
+		x = group.Square(x)
+	}
+	return x
+}
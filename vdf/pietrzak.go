@@ -0,0 +1,88 @@
+package vdf
+
+//	bits of the Fiat-Shamir challenges
+const challengeBits = 128
+
+//	Pietrzak VDF, the proof is the list of midpoints mu_i = x_i^(2^(T_i / 2)) of a halving protocol:
+//	x_{i+1} = x_i^r_i * mu_i, y_{i+1} = mu_i^r_i * y_i, T_{i+1} = T_i / 2, until y = x^2.
+//	an odd T_i is first made even with y_i = y_i^2, T_i = T_i + 1.
+//	soundness relies on the low order assumption: a prover who finds an element of small
+//	order can make a wrong output pass. the signed RSAGroup has no known element of low order,
+//	the class group of a prime discriminant has none of order 2, but that elements of other
+//	small orders are hard to find is only assumed, Wesolowski does not need the assumption
+type Pietrzak struct {
+	group      Group
+	difficulty uint64
+}
+
+//	create Pietrzak VDF of difficulty squarings over group
+func NewPietrzak(group Group, difficulty uint64) (*Pietrzak, error) {
+	if difficulty == 0 {
+		return nil, ErrDifficulty
+	}
+	return &Pietrzak{group: group, difficulty: difficulty}, nil
+}
+
+func (v *Pietrzak) Difficulty() uint64 {
+	return v.difficulty
+}
+
+//	y = x^(2^T)
+func (v *Pietrzak) Eval(challenge []byte) []byte {
+	return eval(v.group, v.difficulty, challenge)
+}
+
+//	log2(T) midpoints, each one is recomputed from x_i so that proving takes about T squarings
+func (v *Pietrzak) Prove(challenge []byte, output []byte) ([]byte, error) {
+	x := v.group.HashToElement(challenge)
+	y, err := v.group.Decode(output)
+	if err != nil {
+		return nil, err
+	}
+
+	var proof []byte
+	for t := v.difficulty; t > 1; t /= 2 {
+		if t%2 == 1 {
+			y = v.group.Square(y)
+			t++
+		}
+		mu := repeatedSquare(v.group, x, t/2)
+		muB := v.group.Encode(mu)
+		proof = append(proof, muB...)
+
+		r := hashToInt("VDF_PIETRZAK_CHALLENGE_", challengeBits, t, v.group.Encode(x), v.group.Encode(y), muB)
+		x = v.group.Mul(Exp(v.group, x, r), mu)
+		y = v.group.Mul(Exp(v.group, mu, r), y)
+	}
+	return proof, nil
+}
+
+//	replay the halving protocol and check y == x^2 at the end
+func (v *Pietrzak) Verify(challenge []byte, output []byte, proof []byte) bool {
+	y, err := v.group.Decode(output)
+	if err != nil {
+		return false
+	}
+	x := v.group.HashToElement(challenge)
+	size := len(v.group.Encode(x))
+
+	for t := v.difficulty; t > 1; t /= 2 {
+		if len(proof) < size {
+			return false
+		}
+		if t%2 == 1 {
+			y = v.group.Square(y)
+			t++
+		}
+		mu, err := v.group.Decode(proof[:size])
+		if err != nil {
+			return false
+		}
+
+		r := hashToInt("VDF_PIETRZAK_CHALLENGE_", challengeBits, t, v.group.Encode(x), v.group.Encode(y), proof[:size])
+		x = v.group.Mul(Exp(v.group, x, r), mu)
+		y = v.group.Mul(Exp(v.group, mu, r), y)
+		proof = proof[size:]
+	}
+	return len(proof) == 0 && v.group.Equal(v.group.Square(x), y)
+}
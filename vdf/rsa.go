@@ -0,0 +1,108 @@
+package vdf
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	"go-cryptology/rsa"
+)
+
+var ErrModulus = errors.New("[VDF] modulus must be odd and at least 512 bits")
+
+//	signed quadratic residues QR+_N = {|x| : x in QR_N}, |x| = min(x, N - x).
+//	the order of the group is unknown to anyone who does not know the factors of N,
+//	and unlike Z_N^* it has no element of order 2 that a prover could multiply into the output
+type RSAGroup struct {
+	n    *big.Int
+	half *big.Int
+	size int
+}
+
+//	group of a modulus whose factorization is unknown, e.g. the RSA-2048 challenge
+func NewRSAGroup(modulus *big.Int) (*RSAGroup, error) {
+	if modulus.BitLen() < 512 || modulus.Bit(0) == 0 {
+		return nil, ErrModulus
+	}
+	return &RSAGroup{
+		n:    new(big.Int).Set(modulus),
+		half: new(big.Int).Rsh(modulus, 1),
+		size: (modulus.BitLen() + 7) / 8,
+	}, nil
+}
+
+//	trusted setup: the modulus of a fresh RSA key, the factors are discarded
+func GenRSAGroup(bits int) *RSAGroup {
+	priKey, _ := rsa.GenRSAKeyBits(bits)
+	group, err := NewRSAGroup(priKey.N)
+	if err != nil {
+		panic(err)
+	}
+	return group
+}
+
+//	modulus of the group
+func (g *RSAGroup) Modulus() *big.Int {
+	return new(big.Int).Set(g.n)
+}
+
+//	|H(challenge)^2 mod N|, the hash is expanded to twice the size of the modulus
+func (g *RSAGroup) HashToElement(challenge []byte) Element {
+	digest := sha256.Sum256(challenge)
+	var expanded []byte
+	var counter [4]byte
+	for i := uint32(0); len(expanded) < 2*g.size; i++ {
+		binary.BigEndian.PutUint32(counter[:], i)
+		h := sha256.New()
+		h.Write([]byte("VDF_RSA_HASH_TO_ELEMENT_"))
+		h.Write(counter[:])
+		h.Write(digest[:])
+		expanded = h.Sum(expanded)
+	}
+	x := new(big.Int).SetBytes(expanded[:2*g.size])
+	x.Mod(x, g.n)
+	return g.Square(x)
+}
+
+func (g *RSAGroup) Identity() Element {
+	return big.NewInt(1)
+}
+
+func (g *RSAGroup) Mul(x Element, y Element) Element {
+	z := new(big.Int).Mul(x.(*big.Int), y.(*big.Int))
+	return g.abs(z.Mod(z, g.n))
+}
+
+func (g *RSAGroup) Square(x Element) Element {
+	return g.Mul(x, x)
+}
+
+func (g *RSAGroup) Equal(x Element, y Element) bool {
+	return x.(*big.Int).Cmp(y.(*big.Int)) == 0
+}
+
+//	big-endian, the size of the modulus
+func (g *RSAGroup) Encode(x Element) []byte {
+	b := make([]byte, g.size)
+	return x.(*big.Int).FillBytes(b)
+}
+
+//	element must be in [1, N/2] and coprime to N
+func (g *RSAGroup) Decode(b []byte) (Element, error) {
+	if len(b) != g.size {
+		return nil, ErrInvalidElement
+	}
+	x := new(big.Int).SetBytes(b)
+	if x.Sign() == 0 || x.Cmp(g.half) > 0 || new(big.Int).GCD(nil, nil, x, g.n).Cmp(big.NewInt(1)) != 0 {
+		return nil, ErrInvalidElement
+	}
+	return x, nil
+}
+
+func (g *RSAGroup) abs(x *big.Int) *big.Int {
+	if x.Cmp(g.half) > 0 {
+		x.Sub(g.n, x)
+	}
+	return x
+}
@@ -0,0 +1,40 @@
+package vdf
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math/big"
+)
+
+var (
+	ErrDifficulty = errors.New("[VDF] difficulty must be positive")
+	ErrProof      = errors.New("[VDF] malformed proof")
+)
+
+//	verifiable delay function y = x^(2^T) with x = H(challenge), T is the difficulty.
+//	Eval takes T sequential squarings, Prove about as many again and Verify is fast
+type VDF interface {
+	Difficulty() uint64
+	Eval(challenge []byte) (output []byte)
+	Prove(challenge []byte, output []byte) (proof []byte, err error)
+	Verify(challenge []byte, output []byte, proof []byte) bool
+}
+
+func eval(group Group, difficulty uint64, challenge []byte) []byte {
+	return group.Encode(repeatedSquare(group, group.HashToElement(challenge), difficulty))
+}
+
+//	SHA256(domain || T || elements) as an integer of bits
+func hashToInt(domain string, bits int, difficulty uint64, elements ...[]byte) *big.Int {
+	var t [8]byte
+	binary.BigEndian.PutUint64(t[:], difficulty)
+	h := sha256.New()
+	h.Write([]byte(domain))
+	h.Write(t[:])
+	for _, e := range elements {
+		h.Write(e)
+	}
+	r := new(big.Int).SetBytes(h.Sum(nil))
+	return r.Rsh(r, uint(sha256.Size*8-bits))
+}
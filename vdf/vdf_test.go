@@ -0,0 +1,102 @@
+package vdf
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+)
+
+var (
+	testRSAGroup   = GenRSAGroup(1024)
+	testClassGroup = ClassGroupFromSeed([]byte("go-cryptology vdf test"), 512)
+)
+
+func testVDF(t *testing.T, v VDF) {
+	challenge := []byte("beacon round 42")
+	output := v.Eval(challenge)
+	proof, err := v.Prove(challenge, output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !v.Verify(challenge, output, proof) {
+		t.Fatalf("got result %v but expected %v\n", false, true)
+	}
+
+	//	the output of another challenge or with a modified proof is rejected
+	if v.Verify([]byte("beacon round 43"), output, proof) {
+		t.Fatalf("got result %v but expected %v\n", true, false)
+	}
+	other := v.Eval([]byte("beacon round 43"))
+	if v.Verify(challenge, other, proof) {
+		t.Fatalf("got result %v but expected %v\n", true, false)
+	}
+	if v.Verify(challenge, output, append(proof, proof...)) || v.Verify(challenge, output, proof[1:]) {
+		t.Fatalf("got result %v but expected %v\n", true, false)
+	}
+}
+
+func TestWesolowski(t *testing.T) {
+	fmt.Println("Test : Wesolowski VDF ...")
+
+	t0 := time.Now()
+
+	for _, group := range []Group{testRSAGroup, testClassGroup} {
+		for _, difficulty := range []uint64{1, 2, 1000} {
+			v, err := NewWesolowski(group, difficulty)
+			if err != nil {
+				t.Fatal(err)
+			}
+			testVDF(t, v)
+		}
+	}
+
+	if _, err := NewWesolowski(testRSAGroup, 0); err != ErrDifficulty {
+		t.Fatalf("got result %v but expected %v\n", err, ErrDifficulty)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func TestPietrzak(t *testing.T) {
+	fmt.Println("Test : Pietrzak VDF ...")
+
+	t0 := time.Now()
+
+	for _, group := range []Group{testRSAGroup, testClassGroup} {
+		//	odd difficulties take the squaring branch
+		for _, difficulty := range []uint64{2, 3, 1000, 1023} {
+			v, err := NewPietrzak(group, difficulty)
+			if err != nil {
+				t.Fatal(err)
+			}
+			testVDF(t, v)
+		}
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func TestRSAGroup(t *testing.T) {
+	fmt.Println("Test : VDF RSA group ...")
+
+	t0 := time.Now()
+
+	g := testRSAGroup
+	x := g.HashToElement([]byte("hello world"))
+	//	-1 is identified with 1 in the signed group
+	minusOne := new(big.Int).Sub(g.Modulus(), big.NewInt(1))
+	if !g.Equal(g.Mul(x, g.abs(new(big.Int).Set(minusOne))), x) {
+		t.Fatalf("got result %v but expected %v\n", false, true)
+	}
+
+	decoded, err := g.Decode(g.Encode(x))
+	if err != nil || !g.Equal(decoded, x) {
+		t.Fatalf("got result %v but expected %v\n", decoded, x)
+	}
+	if _, err := g.Decode(minusOne.FillBytes(make([]byte, len(g.Encode(x))))); err != ErrInvalidElement {
+		t.Fatalf("got result %v but expected %v\n", err, ErrInvalidElement)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
@@ -0,0 +1,86 @@
+package vdf
+
+import (
+	"encoding/binary"
+	"math/big"
+)
+
+//	bits of the Fiat-Shamir prime
+const primeBits = 256
+
+//	Wesolowski VDF, the proof is the single element pi = x^floor(2^T / l)
+//	for a prime l derived from x and y, the verifier checks pi^l * x^(2^T mod l) == y
+type Wesolowski struct {
+	group      Group
+	difficulty uint64
+}
+
+//	create Wesolowski VDF of difficulty squarings over group
+func NewWesolowski(group Group, difficulty uint64) (*Wesolowski, error) {
+	if difficulty == 0 {
+		return nil, ErrDifficulty
+	}
+	return &Wesolowski{group: group, difficulty: difficulty}, nil
+}
+
+func (v *Wesolowski) Difficulty() uint64 {
+	return v.difficulty
+}
+
+//	y = x^(2^T)
+func (v *Wesolowski) Eval(challenge []byte) []byte {
+	return eval(v.group, v.difficulty, challenge)
+}
+
+//	pi = x^floor(2^T / l), the quotient is produced bit by bit by long division
+//	so that it is never held in memory
+func (v *Wesolowski) Prove(challenge []byte, output []byte) ([]byte, error) {
+	x := v.group.HashToElement(challenge)
+	if _, err := v.group.Decode(output); err != nil {
+		return nil, err
+	}
+	l := v.prime(v.group.Encode(x), output)
+
+	pi := v.group.Identity()
+	r := big.NewInt(1)
+	for i := uint64(0); i < v.difficulty; i++ {
+		// next bit of the quotient is floor(2r / l), r = 2r mod l
+		r.Lsh(r, 1)
+		pi = v.group.Square(pi)
+		if r.Cmp(l) >= 0 {
+			r.Sub(r, l)
+			pi = v.group.Mul(pi, x)
+		}
+	}
+	return v.group.Encode(pi), nil
+}
+
+//	pi^l * x^(2^T mod l) == y
+func (v *Wesolowski) Verify(challenge []byte, output []byte, proof []byte) bool {
+	y, err := v.group.Decode(output)
+	if err != nil {
+		return false
+	}
+	pi, err := v.group.Decode(proof)
+	if err != nil {
+		return false
+	}
+	x := v.group.HashToElement(challenge)
+	l := v.prime(v.group.Encode(x), output)
+
+	r := new(big.Int).Exp(bigTwo, new(big.Int).SetUint64(v.difficulty), l)
+	return v.group.Equal(v.group.Mul(Exp(v.group, pi, l), Exp(v.group, x, r)), y)
+}
+
+//	first prime of H(x || y || counter) of primeBits
+func (v *Wesolowski) prime(x []byte, y []byte) *big.Int {
+	var counter [8]byte
+	for i := uint64(0); ; i++ {
+		binary.BigEndian.PutUint64(counter[:], i)
+		l := hashToInt("VDF_WESOLOWSKI_PRIME_", primeBits, v.difficulty, x, y, counter[:])
+		l.SetBit(l, primeBits-1, 1)
+		if l.ProbablyPrime(20) {
+			return l
+		}
+	}
+}
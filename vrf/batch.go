@@ -0,0 +1,253 @@
+package vrf
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+
+	"filippo.io/edwards25519"
+	coname "github.com/yahoo/coname/ed25519/edwards25519"
+	"golang.org/x/crypto/sha3"
+)
+
+//	ii || r*G || r*H(m) || t, the challenge c is recomputed from the commitments
+const BatchableProofSize = 4 * 32
+
+//	l - 1, the largest canonical scalar
+var orderMinusOne = new(edwards25519.Scalar).Subtract(edwards25519.NewScalar(), func() *edwards25519.Scalar {
+	one := [32]byte{1}
+	s, _ := new(edwards25519.Scalar).SetCanonicalBytes(one[:])
+	return s
+}())
+
+var (
+	ErrBatchLength = errors.New("[VRF] number of public keys, messages, random numbers and proofs differ")
+	ErrBatchFailed = errors.New("[VRF] batch contains invalid proofs")
+)
+
+//	generate random number and a proof that carries the commitments,
+//	so that many proofs can be checked with one multiscalar multiplication
+func ProveBatchable(message []byte, priKey *[PrivateKeySize]byte) (vrf []byte, proof []byte) {
+	vrf, _, t, iiB, grB, hrB := prove(message, priKey)
+
+	proof = make([]byte, 0, BatchableProofSize)
+	proof = append(proof, iiB[:]...)
+	proof = append(proof, grB[:]...)
+	proof = append(proof, hrB[:]...)
+	proof = append(proof, t[:]...)
+	return vrf, proof
+}
+
+//	generate random number and its batchable proof
+func (k *PrivateKey) ProveBatchable(message []byte) (vrf []byte, proof []byte) {
+	return ProveBatchable(message, (*[PrivateKeySize]byte)(k))
+}
+
+//	c || t || ii proof accepted by Verify of a batchable proof
+func CompressProof(message []byte, proof []byte) ([]byte, error) {
+	if len(proof) != BatchableProofSize {
+		return nil, fmt.Errorf("%w: proof is %d bytes, expected %d", ErrBadLength, len(proof), BatchableProofSize)
+	}
+	c := challenge(proof[32:64], proof[64:96], message)
+
+	compressed := make([]byte, ProofSize)
+	copy(compressed[:32], c[:])
+	copy(compressed[32:64], proof[96:])
+	copy(compressed[64:], proof[:32])
+	return compressed, nil
+}
+
+//	verify many batchable proofs together: with random z_i, w_i of 128 bits,
+//	8 * sum(z_i * (c_i*P_i + t_i*G - A_i) + w_i * (c_i*ii_i + t_i*H_i - B_i)) == 0.
+//	P_i and ii_i must be in the prime order subgroup, A_i and B_i are checked up to
+//	a small order component as in cofactored Ed25519 verification.
+//	when the batch fails every proof is checked alone, err is ErrBatchFailed
+//	and errs holds the reason of each invalid entry, it is nil for valid entries.
+//	proofs of Prove carry the challenge instead of the commitments, they can not be
+//	batched and are checked alone by Verify, their results are reported in errs the same way
+func BatchVerify(pubKeys [][]byte, messages [][]byte, vrfs [][]byte, proofs [][]byte) (errs []error, err error) {
+	if len(pubKeys) != len(messages) || len(pubKeys) != len(vrfs) || len(pubKeys) != len(proofs) {
+		return nil, ErrBatchLength
+	}
+
+	errs = make([]error, len(proofs))
+	failed := false
+	var batch []*batchEntry
+	// the subgroup check of a public key is a full scalar multiplication, keys repeat across a committee
+	points := make(map[string]*edwards25519.Point)
+	for i := range proofs {
+		if len(proofs[i]) == ProofSize {
+			errs[i] = verify(messages[i], pubKeys[i], vrfs[i], proofs[i])
+			failed = failed || errs[i] != nil
+			continue
+		}
+		var entry *batchEntry
+		entry, errs[i] = newBatchEntry(messages[i], pubKeys[i], vrfs[i], proofs[i], points)
+		if entry != nil {
+			entry.index = i
+			batch = append(batch, entry)
+		}
+		failed = failed || errs[i] != nil
+	}
+
+	if len(batch) > 0 && !checkBatch(batch) {
+		for _, entry := range batch {
+			if !checkBatch([]*batchEntry{entry}) {
+				errs[entry.index] = ErrProofMismatch
+				failed = true
+			}
+		}
+	}
+
+	if failed {
+		return errs, ErrBatchFailed
+	}
+	return nil, nil
+}
+
+//	verify a batchable proof, the error is nil if the proof is valid
+func VerifyBatchable(message []byte, pubKey []byte, vrf []byte, proof []byte) error {
+	entry, err := newBatchEntry(message, pubKey, vrf, proof, nil)
+	if err != nil {
+		return err
+	}
+	if !checkBatch([]*batchEntry{entry}) {
+		return ErrProofMismatch
+	}
+	return nil
+}
+
+type batchEntry struct {
+	index         int
+	pubKey, ii, h *edwards25519.Point
+	gr, hr        *edwards25519.Point
+	c, t          *edwards25519.Scalar
+}
+
+//	decode one batchable proof and check everything but the proof equation
+func newBatchEntry(message []byte, pubKey []byte, vrf []byte, proof []byte, points map[string]*edwards25519.Point) (*batchEntry, error) {
+	if len(pubKey) != PublicKeySize {
+		return nil, fmt.Errorf("%w: public key is %d bytes, expected %d", ErrBadLength, len(pubKey), PublicKeySize)
+	}
+	if len(vrf) != Size {
+		return nil, fmt.Errorf("%w: random number is %d bytes, expected %d", ErrBadLength, len(vrf), Size)
+	}
+	if len(proof) != BatchableProofSize {
+		return nil, fmt.Errorf("%w: proof is %d bytes, expected %d", ErrBadLength, len(proof), BatchableProofSize)
+	}
+
+	hash := sha3.NewShake256()
+	hash.Write(proof[:32]) // const length
+	hash.Write(message)
+	var hCheck [Size]byte
+	hash.Read(hCheck[:])
+	if !bytes.Equal(hCheck[:], vrf) {
+		return nil, ErrOutputMismatch
+	}
+
+	var err error
+	entry := new(batchEntry)
+	if entry.pubKey = points[string(pubKey)]; entry.pubKey == nil {
+		if entry.pubKey, err = decodeBaseGroup(pubKey); err != nil {
+			return nil, fmt.Errorf("%w: public key", err)
+		}
+		if points != nil {
+			points[string(pubKey)] = entry.pubKey
+		}
+	}
+	if entry.ii, err = decodeBaseGroup(proof[:32]); err != nil {
+		return nil, fmt.Errorf("%w: proof", err)
+	}
+	if entry.gr, err = decodeCanonical(proof[32:64]); err != nil {
+		return nil, fmt.Errorf("%w: proof", err)
+	}
+	if entry.hr, err = decodeCanonical(proof[64:96]); err != nil {
+		return nil, fmt.Errorf("%w: proof", err)
+	}
+	if entry.t, err = new(edwards25519.Scalar).SetCanonicalBytes(proof[96:]); err != nil {
+		return nil, ErrProofMismatch
+	}
+
+	c := challenge(proof[32:64], proof[64:96], message)
+	entry.c, _ = new(edwards25519.Scalar).SetCanonicalBytes(c[:])
+
+	var hB [32]byte
+	hashToCurve(message).ToBytes(&hB)
+	entry.h, _ = new(edwards25519.Point).SetBytes(hB[:])
+	return entry, nil
+}
+
+//	one multiscalar multiplication over all entries
+func checkBatch(batch []*batchEntry) bool {
+	scalars := make([]*edwards25519.Scalar, 0, 5*len(batch)+1)
+	points := make([]*edwards25519.Point, 0, 5*len(batch)+1)
+
+	tSum := edwards25519.NewScalar()
+	for _, entry := range batch {
+		z, w := randomScalar(), randomScalar()
+		tSum.MultiplyAdd(z, entry.t, tSum)
+
+		scalars = append(scalars,
+			new(edwards25519.Scalar).Multiply(z, entry.c),
+			new(edwards25519.Scalar).Negate(z),
+			new(edwards25519.Scalar).Multiply(w, entry.c),
+			new(edwards25519.Scalar).Multiply(w, entry.t),
+			new(edwards25519.Scalar).Negate(w),
+		)
+		points = append(points, entry.pubKey, entry.gr, entry.ii, entry.h, entry.hr)
+	}
+	scalars = append(scalars, tSum)
+	points = append(points, edwards25519.NewGeneratorPoint())
+
+	sum := new(edwards25519.Point).VarTimeMultiScalarMult(scalars, points)
+	sum.MultByCofactor(sum)
+	return sum.Equal(edwards25519.NewIdentityPoint()) == 1
+}
+
+//	c = SHAKE256(r*G || r*H(m) || m) mod l
+func challenge(grB []byte, hrB []byte, message []byte) [32]byte {
+	var cH [64]byte
+	var c [32]byte
+	hash := sha3.NewShake256()
+	hash.Write(grB) // const length
+	hash.Write(hrB) // const length
+	hash.Write(message)
+	hash.Read(cH[:])
+	coname.ScReduce(&c, &cH)
+	return c
+}
+
+//	the checks of Verify: canonical, not the identity and in the prime order subgroup,
+//	l*P = 0 is checked as (l-1)*P = -P with a variable time multiplication
+func decodeBaseGroup(b []byte) (*edwards25519.Point, error) {
+	P, err := decodeCanonical(b)
+	if err != nil || P.Equal(edwards25519.NewIdentityPoint()) == 1 {
+		return nil, ErrInvalidPoint
+	}
+	lP := new(edwards25519.Point).VarTimeDoubleScalarBaseMult(orderMinusOne, P, edwards25519.NewScalar())
+	if lP.Add(lP, P).Equal(edwards25519.NewIdentityPoint()) != 1 {
+		return nil, ErrInvalidPoint
+	}
+	return P, nil
+}
+
+func decodeCanonical(b []byte) (*edwards25519.Point, error) {
+	P, err := new(edwards25519.Point).SetBytes(b)
+	if err != nil || !bytes.Equal(P.Bytes(), b) {
+		return nil, ErrInvalidPoint
+	}
+	return P, nil
+}
+
+//	uniform scalar of 128 bits
+func randomScalar() *edwards25519.Scalar {
+	var b [32]byte
+	if _, err := io.ReadFull(rand.Reader, b[:16]); err != nil {
+		log.Fatal(err)
+	}
+	s, _ := new(edwards25519.Scalar).SetCanonicalBytes(b[:])
+	return s
+}
@@ -0,0 +1,138 @@
+package vrf
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func genBatch(n int) (pubKeys [][]byte, messages [][]byte, vrfs [][]byte, proofs [][]byte) {
+	for i := 0; i < n; i++ {
+		priKey, pubKey := GenerateKey()
+		message := []byte(fmt.Sprintf("round 1 member %d", i))
+		vrf, proof := priKey.ProveBatchable(message)
+		pubKeys = append(pubKeys, pubKey[:])
+		messages = append(messages, message)
+		vrfs = append(vrfs, vrf)
+		proofs = append(proofs, proof)
+	}
+	return
+}
+
+func TestBatchVerify(t *testing.T) {
+	fmt.Println("Test : vrf batch verify ...")
+
+	pubKeys, messages, vrfs, proofs := genBatch(16)
+
+	t0 := time.Now()
+
+	errs, err := BatchVerify(pubKeys, messages, vrfs, proofs)
+	if err != nil {
+		t.Fatalf("got result %v but expected %v\n", errs, nil)
+	}
+
+	//	batchable proofs compress to proofs of Verify
+	for i := range proofs {
+		if err := VerifyBatchable(messages[i], pubKeys[i], vrfs[i], proofs[i]); err != nil {
+			t.Fatalf("got result %v but expected %v\n", err, nil)
+		}
+		compressed, err := CompressProof(messages[i], proofs[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !Verify(messages[i], pubKeys[i], vrfs[i], compressed) {
+			t.Fatalf("got result %v but expected %v\n", false, true)
+		}
+	}
+
+	//	proofs of Prove are verified alone next to the batch
+	priKey, pubKey := GenerateKey()
+	vrf, proof := priKey.Prove([]byte("hello world"))
+	pubKeys, messages = append(pubKeys, pubKey[:], pubKey[:]), append(messages, []byte("hello world"), []byte("hello world"))
+	vrfs, proofs = append(vrfs, vrf, vrf), append(proofs, proof, proof)
+	errs, err = BatchVerify(pubKeys, messages, vrfs, proofs)
+	if err != nil {
+		t.Fatalf("got result %v but expected %v\n", errs, nil)
+	}
+
+	//	an invalid proof of Prove is reported by its index
+	messages[len(messages)-1] = []byte("hello world!")
+	errs, err = BatchVerify(pubKeys, messages, vrfs, proofs)
+	if !errors.Is(err, ErrBatchFailed) {
+		t.Fatalf("got result %v but expected %v\n", err, ErrBatchFailed)
+	}
+	for i := range errs {
+		if (i == len(errs)-1) != (errs[i] != nil) {
+			t.Fatalf("entry %d: got result %v\n", i, errs[i])
+		}
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func TestBatchVerifyReport(t *testing.T) {
+	fmt.Println("Test : vrf batch verify report ...")
+
+	pubKeys, messages, vrfs, proofs := genBatch(8)
+
+	t0 := time.Now()
+
+	//	r*G of entry 2 is replaced by the one of entry 3, the response t of entry 5 is shifted,
+	//	the public key of entry 6 is swapped with entry 7
+	proofs[2] = append(append(append([]byte(nil), proofs[2][:32]...), proofs[3][32:64]...), proofs[2][64:]...)
+	proofs[5] = append([]byte(nil), proofs[5]...)
+	proofs[5][96]++
+	pubKeys[6], pubKeys[7] = pubKeys[7], pubKeys[6]
+	vrfs[4] = vrfs[4][1:]
+
+	errs, err := BatchVerify(pubKeys, messages, vrfs, proofs)
+	if !errors.Is(err, ErrBatchFailed) {
+		t.Fatalf("got result %v but expected %v\n", err, ErrBatchFailed)
+	}
+	wanted := map[int]error{2: ErrProofMismatch, 4: ErrBadLength, 5: ErrProofMismatch, 6: ErrProofMismatch, 7: ErrProofMismatch}
+	for i := range errs {
+		if wanted[i] == nil {
+			if errs[i] != nil {
+				t.Fatalf("entry %d: got result %v but expected %v\n", i, errs[i], nil)
+			}
+		} else if !errors.Is(errs[i], wanted[i]) {
+			t.Fatalf("entry %d: got result %v but expected %v\n", i, errs[i], wanted[i])
+		}
+	}
+
+	if _, err := BatchVerify(pubKeys, messages[1:], vrfs, proofs); !errors.Is(err, ErrBatchLength) {
+		t.Fatalf("got result %v but expected %v\n", err, ErrBatchLength)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func BenchmarkBatchVerify64(b *testing.B) {
+	pubKeys, messages, vrfs, proofs := genBatch(64)
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		BatchVerify(pubKeys, messages, vrfs, proofs)
+	}
+}
+
+func BenchmarkVerify64(b *testing.B) {
+	var pubKeys, messages, vrfs, proofs [][]byte
+	for i := 0; i < 64; i++ {
+		priKey, pubKey := GenerateKey()
+		message := []byte(fmt.Sprintf("round 1 member %d", i))
+		vrf, proof := priKey.Prove(message)
+		pubKeys = append(pubKeys, pubKey[:])
+		messages = append(messages, message)
+		vrfs = append(vrfs, vrf)
+		proofs = append(proofs, proof)
+	}
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for i := range proofs {
+			Verify(messages[i], pubKeys[i], vrfs[i], proofs[i])
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package vrf
+
+import (
+	"crypto/sha512"
+	"errors"
+)
+
+//	RFC 9381 ciphersuite, the value is the suite_string
+type Suite byte
+
+const (
+	P256SHA256TAI          Suite = 0x01
+	Edwards25519SHA512TAI  Suite = 0x03
+	Edwards25519SHA512ELL2 Suite = 0x04
+	//	not in RFC 9381, the P-256 construction over secp256k1 in the private suite range
+	Secp256k1SHA256TAI Suite = 0xfe
+)
+
+const (
+	SeedSize = 32
+	//	Gamma || c || s of the edwards25519 suites
+	ECVRFProofSize = 32 + 16 + 32
+	//	beta of the edwards25519 suites
+	ECVRFSize = sha512.Size
+
+	challengeSize = 16
+)
+
+const (
+	domainEncode    = 0x01
+	domainChallenge = 0x02
+	domainHash      = 0x03
+	domainEnd       = 0x00
+)
+
+var (
+	ErrUnsupportedSuite = errors.New("[VRF] unsupported ciphersuite")
+	ErrInvalidKey       = errors.New("[VRF] invalid key")
+	ErrInvalidProof     = errors.New("[VRF] invalid proof")
+)
+
+//	ECVRF over one ciphersuite, keys, random numbers and proofs are encoded as in RFC 9381
+type VRF interface {
+	Suite() Suite
+	//	size of the proof pi and of the random number beta
+	ProofSize() int
+	Size() int
+	GenVRFKey() (priKey []byte, pubKey []byte)
+	KeyFromSeed(seed []byte) (priKey []byte, pubKey []byte, err error)
+	Prove(message []byte, priKey []byte) (vrf []byte, proof []byte, err error)
+	Verify(message []byte, pubKey []byte, vrf []byte, proof []byte) bool
+	ProofToHash(proof []byte) ([]byte, error)
+}
+
+//	create VRF of the ciphersuite
+func New(suite Suite) (VRF, error) {
+	switch suite {
+	case Edwards25519SHA512TAI, Edwards25519SHA512ELL2:
+		return edwardsVRF(suite), nil
+	case P256SHA256TAI:
+		return p256VRF, nil
+	case Secp256k1SHA256TAI:
+		return secp256k1VRF, nil
+	default:
+		return nil, ErrUnsupportedSuite
+	}
+}
@@ -0,0 +1,216 @@
+package vrf
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha512"
+	"crypto/subtle"
+	"io"
+	"log"
+
+	"filippo.io/edwards25519"
+)
+
+//	ECVRF-EDWARDS25519-SHA512-TAI and -ELL2
+type edwardsVRF Suite
+
+func (s edwardsVRF) Suite() Suite {
+	return Suite(s)
+}
+
+func (s edwardsVRF) ProofSize() int {
+	return ECVRFProofSize
+}
+
+func (s edwardsVRF) Size() int {
+	return ECVRFSize
+}
+
+//	generate private key seed || public key and public key, the same key pair as ed25519
+func (s edwardsVRF) GenVRFKey() (priKey []byte, pubKey []byte) {
+	seed := make([]byte, SeedSize)
+	if _, err := io.ReadFull(rand.Reader, seed); err != nil {
+		log.Fatal(err)
+	}
+	priKey, pubKey, _ = s.KeyFromSeed(seed)
+	return
+}
+
+//	derive key pair from 32-byte seed
+func (s edwardsVRF) KeyFromSeed(seed []byte) (priKey []byte, pubKey []byte, err error) {
+	if len(seed) != SeedSize {
+		return nil, nil, ErrInvalidKey
+	}
+	_, Y, _ := expandSeed(seed)
+	pubKey = Y.Bytes()
+	priKey = append(append(make([]byte, 0, PrivateKeySize), seed...), pubKey...)
+	return priKey, pubKey, nil
+}
+
+//	generate random number and its proof, the proof is 80 bytes and the random number 64 bytes
+func (s edwardsVRF) Prove(message []byte, priKey []byte) (vrf []byte, proof []byte, err error) {
+	if len(priKey) != PrivateKeySize {
+		return nil, nil, ErrInvalidKey
+	}
+
+	x, Y, prefix := expandSeed(priKey[:SeedSize])
+	pubKey := Y.Bytes()
+
+	H, err := s.encodeToCurve(pubKey, message)
+	if err != nil {
+		return nil, nil, err
+	}
+	hString := H.Bytes()
+	gamma := new(edwards25519.Point).ScalarMult(x, H)
+
+	// nonce as in RFC 8032
+	h := sha512.New()
+	h.Write(prefix)
+	h.Write(hString)
+	k, _ := edwards25519.NewScalar().SetUniformBytes(h.Sum(nil))
+
+	kB := new(edwards25519.Point).ScalarBaseMult(k)
+	kH := new(edwards25519.Point).ScalarMult(k, H)
+	cString := s.challenge(pubKey, hString, gamma.Bytes(), kB.Bytes(), kH.Bytes())
+
+	c := challengeScalar(cString)
+	sc := edwards25519.NewScalar().MultiplyAdd(c, x, k)
+
+	proof = make([]byte, 0, ECVRFProofSize)
+	proof = append(proof, gamma.Bytes()...)
+	proof = append(proof, cString...)
+	proof = append(proof, sc.Bytes()...)
+	return s.gammaToHash(gamma), proof, nil
+}
+
+//	verify random number and its proof, small order public keys are rejected
+func (s edwardsVRF) Verify(message []byte, pubKey []byte, vrf []byte, proof []byte) bool {
+	if len(pubKey) != PublicKeySize || len(vrf) != ECVRFSize || len(proof) != ECVRFProofSize {
+		return false
+	}
+
+	Y, err := decodePoint(pubKey)
+	if err != nil || isSmallOrder(Y) {
+		return false
+	}
+	gamma, cString, sc, err := decodeProof(proof)
+	if err != nil {
+		return false
+	}
+
+	H, err := s.encodeToCurve(pubKey, message)
+	if err != nil {
+		return false
+	}
+
+	// U = s*B - c*Y, V = s*H - c*Gamma
+	minusC := edwards25519.NewScalar().Negate(challengeScalar(cString))
+	U := new(edwards25519.Point).VarTimeDoubleScalarBaseMult(minusC, Y, sc)
+	V := new(edwards25519.Point).VarTimeMultiScalarMult([]*edwards25519.Scalar{sc, minusC}, []*edwards25519.Point{H, gamma})
+
+	expected := s.challenge(pubKey, H.Bytes(), gamma.Bytes(), U.Bytes(), V.Bytes())
+	if subtle.ConstantTimeCompare(expected, cString) != 1 {
+		return false
+	}
+	return subtle.ConstantTimeCompare(s.gammaToHash(gamma), vrf) == 1
+}
+
+//	random number of a proof, the proof itself is not verified
+func (s edwardsVRF) ProofToHash(proof []byte) ([]byte, error) {
+	if len(proof) != ECVRFProofSize {
+		return nil, ErrInvalidProof
+	}
+	gamma, _, _, err := decodeProof(proof)
+	if err != nil {
+		return nil, err
+	}
+	return s.gammaToHash(gamma), nil
+}
+
+func (s edwardsVRF) encodeToCurve(salt []byte, message []byte) (*edwards25519.Point, error) {
+	if Suite(s) == Edwards25519SHA512ELL2 {
+		return encodeToCurveELL2(Suite(s), salt, message), nil
+	}
+	return encodeToCurveTAI(Suite(s), salt, message)
+}
+
+//	Hash(suite_string || 0x02 || P1 || ... || P5 || 0x00) truncated to 16 bytes
+func (s edwardsVRF) challenge(points ...[]byte) []byte {
+	h := sha512.New()
+	h.Write([]byte{byte(s), domainChallenge})
+	for _, p := range points {
+		h.Write(p)
+	}
+	h.Write([]byte{domainEnd})
+	return h.Sum(nil)[:challengeSize]
+}
+
+//	beta = Hash(suite_string || 0x03 || cofactor * Gamma || 0x00)
+func (s edwardsVRF) gammaToHash(gamma *edwards25519.Point) []byte {
+	h := sha512.New()
+	h.Write([]byte{byte(s), domainHash})
+	h.Write(new(edwards25519.Point).MultByCofactor(gamma).Bytes())
+	h.Write([]byte{domainEnd})
+	return h.Sum(nil)
+}
+
+//	try-and-increment: the first Hash(suite_string || 0x01 || salt || message || ctr || 0x00)
+//	that decodes to a point, multiplied by the cofactor
+func encodeToCurveTAI(s Suite, salt []byte, message []byte) (*edwards25519.Point, error) {
+	for ctr := 0; ctr < 256; ctr++ {
+		h := sha512.New()
+		h.Write([]byte{byte(s), domainEncode})
+		h.Write(salt)
+		h.Write(message)
+		h.Write([]byte{byte(ctr), domainEnd})
+		if p, err := decodePoint(h.Sum(nil)[:32]); err == nil {
+			return p.MultByCofactor(p), nil
+		}
+	}
+	return nil, ErrInvalidProof
+}
+
+//	secret scalar, public key and nonce prefix of the seed as in RFC 8032
+func expandSeed(seed []byte) (x *edwards25519.Scalar, Y *edwards25519.Point, prefix []byte) {
+	digest := sha512.Sum512(seed)
+	x, _ = edwards25519.NewScalar().SetBytesWithClamping(digest[:32])
+	Y = new(edwards25519.Point).ScalarBaseMult(x)
+	return x, Y, digest[32:]
+}
+
+//	Gamma, c and s of the proof, s must be reduced
+func decodeProof(proof []byte) (gamma *edwards25519.Point, c []byte, s *edwards25519.Scalar, err error) {
+	gamma, err = decodePoint(proof[:32])
+	if err != nil {
+		return nil, nil, nil, ErrInvalidProof
+	}
+	s, err = edwards25519.NewScalar().SetCanonicalBytes(proof[32+challengeSize:])
+	if err != nil {
+		return nil, nil, nil, ErrInvalidProof
+	}
+	return gamma, proof[32 : 32+challengeSize], s, nil
+}
+
+//	RFC 8032 decoding, non-canonical encodings are rejected
+func decodePoint(b []byte) (*edwards25519.Point, error) {
+	p, err := new(edwards25519.Point).SetBytes(b)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(p.Bytes(), b) {
+		return nil, ErrInvalidKey
+	}
+	return p, nil
+}
+
+func isSmallOrder(p *edwards25519.Point) bool {
+	return new(edwards25519.Point).MultByCofactor(p).Equal(edwards25519.NewIdentityPoint()) == 1
+}
+
+//	16-byte little-endian challenge as a scalar, it is always reduced
+func challengeScalar(c []byte) *edwards25519.Scalar {
+	var buf [32]byte
+	copy(buf[:], c)
+	scalar, _ := edwards25519.NewScalar().SetCanonicalBytes(buf[:])
+	return scalar
+}
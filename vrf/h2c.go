@@ -0,0 +1,105 @@
+package vrf
+
+import (
+	"crypto/sha512"
+	"math/big"
+
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+	"go-cryptology/internal/h2c"
+)
+
+const h2cSuiteEdwards25519 = "edwards25519_XMD:SHA-512_ELL2_NU_"
+
+var (
+	fieldPrime = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19))
+
+	feOne = new(field.Element).One()
+	//	Montgomery A of curve25519
+	montA = new(field.Element).Mult32(feOne, 486662)
+	//	sqrt(-486664) with sgn0 = 0, scales the rational map to edwards25519
+	sqrtMinusAPlus2 = func() *field.Element {
+		v := new(field.Element).Mult32(feOne, 486664)
+		v.Negate(v)
+		r, _ := new(field.Element).SqrtRatio(v, feOne)
+		return r
+	}()
+)
+
+//	RFC 9380 encode_to_curve edwards25519_XMD:SHA-512_ELL2_NU_
+//	with DST = "ECVRF_" || h2c_suite_ID_string || suite_string
+func encodeToCurveELL2(s Suite, salt []byte, message []byte) *edwards25519.Point {
+	dst := append([]byte("ECVRF_"+h2cSuiteEdwards25519), byte(s))
+	msg := append(append([]byte(nil), salt...), message...)
+
+	// hash_to_field with L = 48, 48 bytes never exceed the bound of expand_message_xmd
+	uniform, _ := h2c.ExpandMessageXMD(sha512.New, msg, dst, 48)
+	u := new(big.Int).SetBytes(uniform)
+	u.Mod(u, fieldPrime)
+
+	p := mapToCurveElligator2(fieldElement(u))
+	return p.MultByCofactor(p)
+}
+
+//	field element of an integer reduced mod 2^255 - 19, SetBytes takes 32 little-endian bytes
+func fieldElement(n *big.Int) *field.Element {
+	var buf [32]byte
+	b := n.Bytes()
+	for i := range b {
+		buf[i] = b[len(b)-1-i]
+	}
+	fe, _ := new(field.Element).SetBytes(buf[:])
+	return fe
+}
+
+//	Elligator 2 onto curve25519 with Z = 2, followed by the rational map
+//	(x, y) = (sqrt(-486664) * s / t, (s - 1) / (s + 1)) onto edwards25519
+func mapToCurveElligator2(u *field.Element) *edwards25519.Point {
+	minusA := new(field.Element).Negate(montA)
+
+	// x1 = -A / (1 + 2u^2), inv0(0) = 0
+	tv := new(field.Element).Square(u)
+	tv.Add(tv, tv)
+	tv.Add(tv, feOne)
+	x1 := new(field.Element).Multiply(minusA, new(field.Element).Invert(tv))
+	if x1.Equal(new(field.Element).Zero()) == 1 {
+		x1.Set(minusA)
+	}
+	x2 := new(field.Element).Subtract(minusA, x1)
+
+	var s, t *field.Element
+	if y1, wasSquare := new(field.Element).SqrtRatio(montgomeryRHS(x1), feOne); wasSquare == 1 {
+		// sgn0(y) = 1
+		s, t = x1, y1.Negate(y1)
+	} else {
+		// sgn0(y) = 0
+		y2, _ := new(field.Element).SqrtRatio(montgomeryRHS(x2), feOne)
+		s, t = x2, y2
+	}
+
+	sPlusOne := new(field.Element).Add(s, feOne)
+	zero := new(field.Element).Zero()
+	if t.Equal(zero) == 1 || sPlusOne.Equal(zero) == 1 {
+		return edwards25519.NewIdentityPoint()
+	}
+
+	x := new(field.Element).Multiply(sqrtMinusAPlus2, s)
+	x.Multiply(x, new(field.Element).Invert(t))
+	y := new(field.Element).Subtract(s, feOne)
+	y.Multiply(y, new(field.Element).Invert(sPlusOne))
+
+	p, err := new(edwards25519.Point).SetExtendedCoordinates(x, y, new(field.Element).One(), new(field.Element).Multiply(x, y))
+	if err != nil {
+		// the rational map always lands on the curve
+		panic(err)
+	}
+	return p
+}
+
+//	s^3 + A*s^2 + s
+func montgomeryRHS(s *field.Element) *field.Element {
+	v := new(field.Element).Add(s, montA)
+	v.Multiply(v, s)
+	v.Add(v, feOne)
+	return v.Multiply(v, s)
+}
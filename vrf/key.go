@@ -0,0 +1,256 @@
+package vrf
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+
+	"github.com/yahoo/coname/ed25519/edwards25519"
+)
+
+var (
+	ErrBadLength         = errors.New("[VRF] bad length")
+	ErrInvalidPoint      = errors.New("[VRF] invalid curve point")
+	ErrProofMismatch     = errors.New("[VRF] proof mismatch")
+	ErrOutputMismatch    = errors.New("[VRF] random number does not match the proof")
+	ErrPublicKeyMismatch = errors.New("[VRF] embedded public key does not match the seed")
+	ErrPEM               = errors.New("[VRF] malformed PEM block")
+	ErrKeyAlgorithm      = errors.New("[VRF] key is not a key of this VRF")
+)
+
+//	the keys are not Ed25519 keys, so they get their own PEM types
+const (
+	pemPrivateKey = "VRF PRIVATE KEY"
+	pemPublicKey  = "VRF PUBLIC KEY"
+)
+
+//	algorithm identifier of this VRF, the OID 2.25.<uuid> of ITU-T X.667 for the UUID
+//	99662f5d-17a6-4942-a241-d1cc13b2d364. the UUID arc does not fit asn1.ObjectIdentifier,
+//	so the DER encoding is built by hand and compared as raw bytes
+var oidVRF = uuidOID("99662f5d17a64942a241d1cc13b2d364")
+
+//	OneAsymmetricKey of RFC 5958 without attributes
+type pkcs8 struct {
+	Version    int
+	Algorithm  algorithmIdentifier
+	PrivateKey []byte
+}
+
+//	SubjectPublicKeyInfo of RFC 5280
+type publicKeyInfo struct {
+	Algorithm algorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+type algorithmIdentifier struct {
+	Algorithm  asn1.RawValue
+	Parameters asn1.RawValue `asn1:"optional"`
+}
+
+//	DER OBJECT IDENTIFIER 2.25.uuid, arcs are base 128 with the high bit set on all but the last byte
+func uuidOID(uuid string) []byte {
+	arc, _ := new(big.Int).SetString(uuid, 16)
+	var body []byte
+	for last := true; ; last = false {
+		b := byte(new(big.Int).And(arc, big.NewInt(0x7f)).Int64())
+		if !last {
+			b |= 0x80
+		}
+		body = append([]byte{b}, body...)
+		if arc.Rsh(arc, 7).Sign() == 0 {
+			break
+		}
+	}
+	//	the first two arcs 2.25 are encoded together as 2*40 + 25
+	body = append([]byte{2*40 + 25}, body...)
+	return append([]byte{asn1.TagOID, byte(len(body))}, body...)
+}
+
+func newAlgorithmIdentifier() algorithmIdentifier {
+	return algorithmIdentifier{Algorithm: asn1.RawValue{FullBytes: oidVRF}}
+}
+
+//	the algorithm must be this VRF without parameters
+func (a algorithmIdentifier) check() error {
+	if !bytes.Equal(a.Algorithm.FullBytes, oidVRF) || len(a.Parameters.FullBytes) > 0 {
+		return fmt.Errorf("%w: algorithm %x", ErrKeyAlgorithm, a.Algorithm.FullBytes)
+	}
+	return nil
+}
+
+//	private key of the legacy construction, seed || public key
+type PrivateKey [PrivateKeySize]byte
+
+//	public key of the legacy construction
+type PublicKey [PublicKeySize]byte
+
+//	generate VRF private key and public key
+func GenerateKey() (*PrivateKey, *PublicKey) {
+	seed := make([]byte, SeedSize)
+	if _, err := io.ReadFull(rand.Reader, seed); err != nil {
+		log.Fatal(err)
+	}
+	priKey, _ := NewKeyFromSeed(seed)
+	return priKey, priKey.Public()
+}
+
+//	derive private key from 32-byte seed
+func NewKeyFromSeed(seed []byte) (*PrivateKey, error) {
+	if len(seed) != SeedSize {
+		return nil, fmt.Errorf("%w: seed is %d bytes, expected %d", ErrBadLength, len(seed), SeedSize)
+	}
+	priKey := new(PrivateKey)
+	copy(priKey[:SeedSize], seed)
+	copy(priKey[SeedSize:], publicKey((*[PrivateKeySize]byte)(priKey))[:])
+	return priKey, nil
+}
+
+//	import seed || public key, the embedded public key must match the seed
+func NewPrivateKey(b []byte) (*PrivateKey, error) {
+	if len(b) != PrivateKeySize {
+		return nil, fmt.Errorf("%w: private key is %d bytes, expected %d", ErrBadLength, len(b), PrivateKeySize)
+	}
+	priKey, _ := NewKeyFromSeed(b[:SeedSize])
+	if subtle.ConstantTimeCompare(priKey[SeedSize:], b[SeedSize:]) != 1 {
+		return nil, ErrPublicKeyMismatch
+	}
+	return priKey, nil
+}
+
+//	import public key, it must be a point of the prime order subgroup
+func NewPublicKey(b []byte) (*PublicKey, error) {
+	if len(b) != PublicKeySize {
+		return nil, fmt.Errorf("%w: public key is %d bytes, expected %d", ErrBadLength, len(b), PublicKeySize)
+	}
+	pubKey := new(PublicKey)
+	copy(pubKey[:], b)
+
+	var P edwards25519.ExtendedGroupElement
+	if !P.FromBytesBaseGroup((*[PublicKeySize]byte)(pubKey)) {
+		return nil, fmt.Errorf("%w: public key", ErrInvalidPoint)
+	}
+	return pubKey, nil
+}
+
+//	seed of the private key
+func (k *PrivateKey) Seed() []byte {
+	return append([]byte(nil), k[:SeedSize]...)
+}
+
+//	public key embedded in the private key
+func (k *PrivateKey) Public() *PublicKey {
+	pubKey := new(PublicKey)
+	copy(pubKey[:], k[SeedSize:])
+	return pubKey
+}
+
+//	generate random number and its proof
+func (k *PrivateKey) Prove(message []byte) (vrf []byte, proof []byte) {
+	return Prove(message, (*[PrivateKeySize]byte)(k))
+}
+
+//	compute random number
+func (k *PrivateKey) Compute(message []byte) []byte {
+	return Compute(message, (*[PrivateKeySize]byte)(k))
+}
+
+//	verify random number and its proof, the error is nil if the proof is valid
+func (k *PublicKey) Verify(message []byte, vrf []byte, proof []byte) error {
+	return verify(message, k[:], vrf, proof)
+}
+
+//	PKCS#8 encoding with the algorithm identifier of this VRF, the private key is the seed
+//	in an OCTET STRING as in RFC 8410. the legacy construction expands the seed with SHAKE256,
+//	so the key is not labelled as Ed25519 and x509 does not derive a wrong public key from it
+func (k *PrivateKey) MarshalPKCS8() ([]byte, error) {
+	seed, err := asn1.Marshal(k[:SeedSize])
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(pkcs8{Algorithm: newAlgorithmIdentifier(), PrivateKey: seed})
+}
+
+//	parse PKCS#8 private key of this VRF, Ed25519 and other keys are rejected
+func ParsePKCS8PrivateKey(der []byte) (*PrivateKey, error) {
+	var key pkcs8
+	if rest, err := asn1.Unmarshal(der, &key); err != nil {
+		return nil, err
+	} else if len(rest) > 0 {
+		return nil, fmt.Errorf("%w: trailing data after PKCS#8 key", ErrBadLength)
+	}
+	if err := key.Algorithm.check(); err != nil {
+		return nil, err
+	}
+	var seed []byte
+	if rest, err := asn1.Unmarshal(key.PrivateKey, &seed); err != nil {
+		return nil, err
+	} else if len(rest) > 0 {
+		return nil, fmt.Errorf("%w: trailing data after seed", ErrBadLength)
+	}
+	return NewKeyFromSeed(seed)
+}
+
+//	PKIX encoding with the algorithm identifier of this VRF
+func (k *PublicKey) MarshalPKIX() ([]byte, error) {
+	return asn1.Marshal(publicKeyInfo{
+		Algorithm: newAlgorithmIdentifier(),
+		PublicKey: asn1.BitString{Bytes: k[:], BitLength: 8 * PublicKeySize},
+	})
+}
+
+//	parse PKIX public key of this VRF, Ed25519 and other keys are rejected
+func ParsePKIXPublicKey(der []byte) (*PublicKey, error) {
+	var key publicKeyInfo
+	if rest, err := asn1.Unmarshal(der, &key); err != nil {
+		return nil, err
+	} else if len(rest) > 0 {
+		return nil, fmt.Errorf("%w: trailing data after PKIX key", ErrBadLength)
+	}
+	if err := key.Algorithm.check(); err != nil {
+		return nil, err
+	}
+	return NewPublicKey(key.PublicKey.RightAlign())
+}
+
+//	PEM block "VRF PRIVATE KEY" of the PKCS#8 encoding
+func (k *PrivateKey) MarshalPEM() ([]byte, error) {
+	der, err := k.MarshalPKCS8()
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: pemPrivateKey, Bytes: der}), nil
+}
+
+//	parse PEM block "VRF PRIVATE KEY"
+func ParsePrivateKeyPEM(data []byte) (*PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != pemPrivateKey {
+		return nil, ErrPEM
+	}
+	return ParsePKCS8PrivateKey(block.Bytes)
+}
+
+//	PEM block "VRF PUBLIC KEY" of the PKIX encoding
+func (k *PublicKey) MarshalPEM() ([]byte, error) {
+	der, err := k.MarshalPKIX()
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: pemPublicKey, Bytes: der}), nil
+}
+
+//	parse PEM block "VRF PUBLIC KEY"
+func ParsePublicKeyPEM(data []byte) (*PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != pemPublicKey {
+		return nil, ErrPEM
+	}
+	return ParsePKIXPublicKey(block.Bytes)
+}
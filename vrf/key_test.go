@@ -0,0 +1,197 @@
+package vrf
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/sha3"
+)
+
+func TestKeyEncoding(t *testing.T) {
+	fmt.Println("Test : vrf key encoding ...")
+
+	priKey, pubKey := GenerateKey()
+
+	t0 := time.Now()
+
+	//	PKCS#8 private key
+	priPEM, err := priKey.MarshalPEM()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsedPriKey, err := ParsePrivateKeyPEM(priPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *parsedPriKey != *priKey {
+		t.Fatalf("got private key %x but expected %x\n", parsedPriKey[:], priKey[:])
+	}
+
+	//	PKIX public key
+	pubPEM, err := pubKey.MarshalPEM()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsedPubKey, err := ParsePublicKeyPEM(pubPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *parsedPubKey != *pubKey {
+		t.Fatalf("got public key %x but expected %x\n", parsedPubKey[:], pubKey[:])
+	}
+
+	//	the private key PEM is not a public key
+	if _, err := ParsePublicKeyPEM(priPEM); !errors.Is(err, ErrPEM) {
+		t.Fatalf("got result %v but expected %v\n", err, ErrPEM)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+//	an Ed25519 key of the same seed has another public key, so the encodings must not be mixed up
+func TestKeyEncodingNotEd25519(t *testing.T) {
+	fmt.Println("Test : vrf key encoding is not taken for Ed25519 ...")
+
+	priKey, pubKey := GenerateKey()
+
+	t0 := time.Now()
+
+	edKey := ed25519.NewKeyFromSeed(priKey.Seed())
+	if bytes.Equal(edKey.Public().(ed25519.PublicKey), pubKey[:]) {
+		t.Fatalf("got Ed25519 public key %x equal to the VRF public key\n", pubKey[:])
+	}
+
+	//	x509 does not parse the VRF keys as Ed25519 keys
+	der, err := priKey.MarshalPKCS8()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key, err := x509.ParsePKCS8PrivateKey(der); err == nil {
+		t.Fatalf("got %T but expected an error\n", key)
+	}
+	der, err = pubKey.MarshalPKIX()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key, err := x509.ParsePKIXPublicKey(der); err == nil {
+		t.Fatalf("got %T but expected an error\n", key)
+	}
+
+	//	and Ed25519 keys are not parsed as VRF keys
+	der, _ = x509.MarshalPKCS8PrivateKey(edKey)
+	if _, err := ParsePKCS8PrivateKey(der); !errors.Is(err, ErrKeyAlgorithm) {
+		t.Fatalf("got result %v but expected %v\n", err, ErrKeyAlgorithm)
+	}
+	der, _ = x509.MarshalPKIXPublicKey(edKey.Public())
+	if _, err := ParsePKIXPublicKey(der); !errors.Is(err, ErrKeyAlgorithm) {
+		t.Fatalf("got result %v but expected %v\n", err, ErrKeyAlgorithm)
+	}
+	block := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	if _, err := ParsePrivateKeyPEM(block); !errors.Is(err, ErrPEM) {
+		t.Fatalf("got result %v but expected %v\n", err, ErrPEM)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func TestNewPrivateKey(t *testing.T) {
+	fmt.Println("Test : vrf import private key ...")
+
+	priKey, pubKey := GenerateKey()
+
+	t0 := time.Now()
+
+	//	seed || public key round trip
+	imported, err := NewPrivateKey(priKey[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(imported.Public()[:], pubKey[:]) {
+		t.Fatalf("got public key %x but expected %x\n", imported.Public()[:], pubKey[:])
+	}
+
+	//	the embedded public key must match the seed
+	tampered := *priKey
+	tampered[PrivateKeySize-1] ^= 1
+	if _, err := NewPrivateKey(tampered[:]); !errors.Is(err, ErrPublicKeyMismatch) {
+		t.Fatalf("got result %v but expected %v\n", err, ErrPublicKeyMismatch)
+	}
+
+	if _, err := NewPrivateKey(priKey[:SeedSize]); !errors.Is(err, ErrBadLength) {
+		t.Fatalf("got result %v but expected %v\n", err, ErrBadLength)
+	}
+
+	//	the typed key agrees with the legacy functions
+	fromSeed, err := NewKeyFromSeed(priKey.Seed())
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := []byte("hello world")
+	vrf, proof := fromSeed.Prove(message)
+	if !Verify(message, pubKey[:], vrf, proof) {
+		t.Fatalf("got result %v but expected %v\n", false, true)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func TestVerifyError(t *testing.T) {
+	fmt.Println("Test : vrf verify error ...")
+
+	priKey, pubKey := GenerateKey()
+
+	t0 := time.Now()
+
+	message := []byte("hello world")
+	vrf, proof := priKey.Prove(message)
+	if err := pubKey.Verify(message, vrf, proof); err != nil {
+		t.Fatalf("got result %v but expected %v\n", err, nil)
+	}
+
+	//	the proof of another key
+	otherKey, _ := GenerateKey()
+	otherVrf, otherProof := otherKey.Prove(message)
+
+	//	y = 2 is not the y-coordinate of a curve point,
+	//	the random number is derived from it so that the point check is reached
+	notOnCurve := append([]byte(nil), proof...)
+	copy(notOnCurve[64:], append([]byte{2}, make([]byte, 31)...))
+	notOnCurveVrf := make([]byte, Size)
+	hash := sha3.NewShake256()
+	hash.Write(notOnCurve[64:])
+	hash.Write(message)
+	hash.Read(notOnCurveVrf)
+
+	tests := []struct {
+		name  string
+		vrf   []byte
+		proof []byte
+		err   error
+	}{
+		{"short random number", vrf[1:], proof, ErrBadLength},
+		{"short proof", vrf, proof[1:], ErrBadLength},
+		{"random number of another proof", otherVrf, proof, ErrOutputMismatch},
+		{"proof of another key", otherVrf, otherProof, ErrProofMismatch},
+		{"gamma not on the curve", notOnCurveVrf, notOnCurve, ErrInvalidPoint},
+	}
+	for _, test := range tests {
+		if err := pubKey.Verify(message, test.vrf, test.proof); !errors.Is(err, test.err) {
+			t.Fatalf("%s: got result %v but expected %v\n", test.name, err, test.err)
+		}
+	}
+
+	if _, err := NewPublicKey(make([]byte, PublicKeySize-1)); !errors.Is(err, ErrBadLength) {
+		t.Fatalf("got result %v but expected %v\n", err, ErrBadLength)
+	}
+	if _, err := NewPublicKey(notOnCurve[64:]); !errors.Is(err, ErrInvalidPoint) {
+		t.Fatalf("got result %v but expected %v\n", err, ErrInvalidPoint)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
@@ -0,0 +1,148 @@
+package vrf
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math/bits"
+)
+
+//	SHUFFLE_ROUND_COUNT of the Ethereum consensus specification
+const ShuffleRounds = 90
+
+//	position / 256 is encoded in 4 bytes
+const maxShuffleCount = 1 << 40
+
+var (
+	ErrIndexOutOfRange = errors.New("[VRF] index out of range")
+	ErrShuffleCount    = errors.New("[VRF] number of items must be in [1, 2^40]")
+)
+
+//	position of index in the swap-or-not shuffle of count items, seeded with a random number.
+//	each of the 90 rounds pairs index with flip = pivot - index mod count and swaps them
+//	if the bit of max(index, flip) in SHA256(seed || round || position / 256) is set
+func ShuffledIndex(index uint64, count uint64, seed []byte) (uint64, error) {
+	if count == 0 || count > maxShuffleCount {
+		return 0, ErrShuffleCount
+	}
+	if index >= count {
+		return 0, ErrIndexOutOfRange
+	}
+	for round := 0; round < ShuffleRounds; round++ {
+		index = swapOrNot(index, count, shufflePivot(seed, round, count), seed, round, nil)
+	}
+	return index, nil
+}
+
+//	inverse of ShuffledIndex, the rounds are involutions applied in reverse order
+func UnshuffledIndex(position uint64, count uint64, seed []byte) (uint64, error) {
+	if count == 0 || count > maxShuffleCount {
+		return 0, ErrShuffleCount
+	}
+	if position >= count {
+		return 0, ErrIndexOutOfRange
+	}
+	for round := ShuffleRounds - 1; round >= 0; round-- {
+		position = swapOrNot(position, count, shufflePivot(seed, round, count), seed, round, nil)
+	}
+	return position, nil
+}
+
+//	whole permutation, permutation[i] = ShuffledIndex(i, count, seed),
+//	the source hashes of a round are computed once for all indices
+func Shuffle(count uint64, seed []byte) ([]uint64, error) {
+	if count == 0 || count > maxShuffleCount {
+		return nil, ErrShuffleCount
+	}
+	permutation := make([]uint64, count)
+	for i := range permutation {
+		permutation[i] = uint64(i)
+	}
+
+	sources := make([][]byte, (count+255)/256)
+	for round := 0; round < ShuffleRounds; round++ {
+		for j := range sources {
+			sources[j] = shuffleSource(seed, round, uint32(j))
+		}
+		pivot := shufflePivot(seed, round, count)
+		for i, index := range permutation {
+			permutation[i] = swapOrNot(index, count, pivot, seed, round, sources)
+		}
+	}
+	return permutation, nil
+}
+
+//	members of committee index out of committees as in compute_committee:
+//	ShuffledIndex(i) for i in [count * index / committees, count * (index + 1) / committees)
+func Committee(index uint64, committees uint64, count uint64, seed []byte) ([]uint64, error) {
+	if index >= committees {
+		return nil, ErrIndexOutOfRange
+	}
+	if count == 0 || count > maxShuffleCount {
+		return nil, ErrShuffleCount
+	}
+	start, end := mulDiv(count, index, committees), mulDiv(count, index+1, committees)
+	members := make([]uint64, 0, end-start)
+	for i := start; i < end; i++ {
+		member, err := ShuffledIndex(i, count, seed)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	return members, nil
+}
+
+//	verify the proof of the legacy construction, then shuffle index with its random number
+func VerifyShuffledIndex(message []byte, pubKey []byte, vrf []byte, proof []byte, index uint64, count uint64) (uint64, error) {
+	if err := verify(message, pubKey, vrf, proof); err != nil {
+		return 0, err
+	}
+	return ShuffledIndex(index, count, vrf)
+}
+
+//	one round of swap-or-not, sources are the precomputed source hashes of the round or nil
+func swapOrNot(index uint64, count uint64, pivot uint64, seed []byte, round int, sources [][]byte) uint64 {
+	flip := (pivot + count - index) % count
+	position := index
+	if flip > position {
+		position = flip
+	}
+
+	var source []byte
+	if sources != nil {
+		source = sources[position/256]
+	} else {
+		source = shuffleSource(seed, round, uint32(position/256))
+	}
+	if (source[(position%256)/8]>>(position%8))&1 == 1 {
+		return flip
+	}
+	return index
+}
+
+//	first 8 bytes of SHA256(seed || round) as a little-endian integer mod count
+func shufflePivot(seed []byte, round int, count uint64) uint64 {
+	h := sha256.New()
+	h.Write(seed)
+	h.Write([]byte{byte(round)})
+	return binary.LittleEndian.Uint64(h.Sum(nil)[:8]) % count
+}
+
+//	SHA256(seed || round || position / 256), the last as 4 little-endian bytes
+func shuffleSource(seed []byte, round int, chunk uint32) []byte {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], chunk)
+	h := sha256.New()
+	h.Write(seed)
+	h.Write([]byte{byte(round)})
+	h.Write(b[:])
+	return h.Sum(nil)
+}
+
+//	a * b / c without overflow, the quotient must fit in 64 bits
+func mulDiv(a uint64, b uint64, c uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	q, _ := bits.Div64(hi, lo, c)
+	return q
+}
@@ -0,0 +1,143 @@
+package vrf
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+//	compute_shuffled_index transcribed from the Ethereum consensus specification
+func specShuffledIndex(index uint64, count uint64, seed []byte) uint64 {
+	for round := 0; round < ShuffleRounds; round++ {
+		pivotHash := sha256.Sum256(append(append([]byte(nil), seed...), byte(round)))
+		pivot := binary.LittleEndian.Uint64(pivotHash[:8]) % count
+		flip := (pivot + count - index) % count
+		position := index
+		if flip > index {
+			position = flip
+		}
+		var chunk [4]byte
+		binary.LittleEndian.PutUint32(chunk[:], uint32(position/256))
+		source := sha256.Sum256(append(append(append([]byte(nil), seed...), byte(round)), chunk[:]...))
+		if (source[(position%256)/8]>>(position%8))%2 == 1 {
+			index = flip
+		}
+	}
+	return index
+}
+
+func TestShuffle(t *testing.T) {
+	fmt.Println("Test : vrf swap-or-not shuffle ...")
+
+	priKey, _ := GenerateKey()
+	seed := priKey.Compute([]byte("epoch 7 committees"))
+
+	t0 := time.Now()
+
+	for _, count := range []uint64{1, 2, 3, 7, 100, 255, 256, 257, 1000} {
+		permutation, err := Shuffle(count, seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		//	bijective: every position is hit exactly once
+		seen := make([]bool, count)
+		for i, position := range permutation {
+			if position >= count || seen[position] {
+				t.Fatalf("count %d: position %d of index %d is out of range or repeated\n", count, position, i)
+			}
+			seen[position] = true
+
+			index, err := ShuffledIndex(uint64(i), count, seed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if index != position || index != specShuffledIndex(uint64(i), count, seed) {
+				t.Fatalf("count %d: got position %v but expected %v\n", count, index, position)
+			}
+			inverse, err := UnshuffledIndex(position, count, seed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if inverse != uint64(i) {
+				t.Fatalf("count %d: got index %v but expected %v\n", count, inverse, i)
+			}
+		}
+	}
+
+	//	another seed gives another permutation
+	p1, _ := Shuffle(100, seed)
+	p2, _ := Shuffle(100, priKey.Compute([]byte("epoch 8 committees")))
+	same := true
+	for i := range p1 {
+		same = same && p1[i] == p2[i]
+	}
+	if same {
+		t.Fatalf("got result %v but expected %v\n", same, false)
+	}
+
+	if _, err := ShuffledIndex(10, 10, seed); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Fatalf("got result %v but expected %v\n", err, ErrIndexOutOfRange)
+	}
+	if _, err := Shuffle(0, seed); !errors.Is(err, ErrShuffleCount) {
+		t.Fatalf("got result %v but expected %v\n", err, ErrShuffleCount)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func TestCommittee(t *testing.T) {
+	fmt.Println("Test : vrf shuffled committees ...")
+
+	priKey, pubKey := GenerateKey()
+	message := []byte("epoch 7 committees")
+	vrf, proof := priKey.Prove(message)
+
+	t0 := time.Now()
+
+	//	the committees partition the validators
+	const count, committees = 1000, 7
+	seen := make(map[uint64]bool, count)
+	for c := uint64(0); c < committees; c++ {
+		members, err := Committee(c, committees, count, vrf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, member := range members {
+			if seen[member] {
+				t.Fatalf("validator %d is in two committees\n", member)
+			}
+			seen[member] = true
+		}
+	}
+	if len(seen) != count {
+		t.Fatalf("got %v validators but expected %v\n", len(seen), count)
+	}
+
+	//	a verifier derives the position from the proof
+	position, err := VerifyShuffledIndex(message, pubKey[:], vrf, proof, 42, count)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wanted, _ := ShuffledIndex(42, count, vrf)
+	if position != wanted {
+		t.Fatalf("got position %v but expected %v\n", position, wanted)
+	}
+	if _, err := VerifyShuffledIndex([]byte("epoch 8 committees"), pubKey[:], vrf, proof, 42, count); err == nil {
+		t.Fatalf("got result %v but expected %v\n", err, ErrOutputMismatch)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func BenchmarkShuffle(b *testing.B) {
+	seed := sha256.Sum256([]byte("hello world"))
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		Shuffle(10000, seed[:])
+	}
+}
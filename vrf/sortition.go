@@ -0,0 +1,160 @@
+package vrf
+
+import (
+	"errors"
+	"math/big"
+)
+
+var (
+	ErrStake        = errors.New("[VRF] stake must not exceed the total stake")
+	ErrExpectedSize = errors.New("[VRF] expected committee size must not exceed the total stake")
+	ErrTotalStake   = errors.New("[VRF] total stake must be positive")
+	ErrEmptyOutput  = errors.New("[VRF] empty random number")
+)
+
+//	Algorand sortition: each unit of stake is a sub-user selected with probability
+//	p = expectedSize / totalStake, the number of selected sub-users is the j with
+//	CDF(j-1) <= vrf / 2^len(vrf) < CDF(j) of the binomial distribution B(stake, p).
+//	the result is exact, the CDF is first evaluated in floating point with a bound on
+//	the rounding error and only a random number within the bound of a CDF value is
+//	compared with exact integers
+func Sortition(vrf []byte, stake uint64, totalStake uint64, expectedSize uint64) (uint64, error) {
+	if totalStake == 0 {
+		return 0, ErrTotalStake
+	}
+	if stake > totalStake {
+		return 0, ErrStake
+	}
+	if expectedSize > totalStake {
+		return 0, ErrExpectedSize
+	}
+	if len(vrf) == 0 {
+		return 0, ErrEmptyOutput
+	}
+	if stake == 0 || expectedSize == 0 {
+		return 0, nil
+	}
+	if expectedSize == totalStake {
+		return stake, nil
+	}
+
+	if j, ok := sortitionFloat(vrf, stake, totalStake, expectedSize); ok {
+		return j, nil
+	}
+	return sortitionExact(vrf, stake, totalStake, expectedSize), nil
+}
+
+//	CDF in big.Float with 128 bits more than the random number. every rounding has a
+//	relative error of at most u = 2^-prec. q^n by square and multiply is within 9nu,
+//	each step of the recurrence adds 6u and each addition u, so the relative error of
+//	every CDF value is below 16nu to first order, the bound eps = (32n + 512)u covers the rest.
+//	ok is false if the random number is within eps of the CDF value it is compared to,
+//	or if q^n underflows the exponent range of big.Float
+func sortitionFloat(vrf []byte, stake uint64, totalStake uint64, expectedSize uint64) (j uint64, ok bool) {
+	L := 8 * len(vrf)
+	prec := uint(L + 128)
+	newFloat := func() *big.Float {
+		return new(big.Float).SetPrec(prec)
+	}
+
+	// ratio = vrf / 2^L, exact
+	ratio := newFloat().SetInt(new(big.Int).SetBytes(vrf))
+	ratio.SetMantExp(ratio, -L)
+
+	eps := newFloat().SetUint64(stake)
+	eps.Mul(eps, newFloat().SetUint64(32))
+	eps.Add(eps, newFloat().SetUint64(512))
+	eps.SetMantExp(eps, -int(prec))
+	lower := newFloat().Sub(newFloat().SetUint64(1), eps)
+	upper := newFloat().Add(newFloat().SetUint64(1), eps)
+
+	T := newFloat().SetUint64(totalStake)
+	p := newFloat().Quo(newFloat().SetUint64(expectedSize), T)
+	q := newFloat().Quo(newFloat().SetUint64(totalStake-expectedSize), T)
+	// p / q, the factor between consecutive terms besides (n - k) / (k + 1)
+	odds := newFloat().Quo(p, q)
+
+	// B(0) = q^n
+	term := newFloat().SetUint64(1)
+	for i := 63; i >= 0; i-- {
+		term.Mul(term, term)
+		if stake>>uint(i)&1 == 1 {
+			term.Mul(term, q)
+		}
+	}
+	if term.Sign() == 0 {
+		return 0, false
+	}
+	cdf := newFloat().Set(term)
+
+	bound, factor := newFloat(), newFloat()
+	for j = 0; j < stake; j++ {
+		if ratio.Cmp(bound.Mul(cdf, lower)) < 0 {
+			return j, true
+		}
+		if ratio.Cmp(bound.Mul(cdf, upper)) < 0 {
+			return 0, false
+		}
+		// B(j+1) = B(j) * (n - j) / (j + 1) * p / q
+		term.Mul(term, factor.SetUint64(stake-j))
+		term.Quo(term, factor.SetUint64(j+1))
+		term.Mul(term, odds)
+		cdf.Add(cdf, term)
+	}
+	return stake, true
+}
+
+//	with L = 8 * len(vrf), T = totalStake, E = expectedSize and n = stake,
+//	vrf / 2^L < CDF(j) is compared as vrf * T^n < 2^L * sum_{i<=j} C(n, i) E^i (T - E)^(n - i).
+//	every term is an integer and the next one is an exact division of the last,
+//	so nothing is normalized, still each step is linear in n log(T) bits
+func sortitionExact(vrf []byte, stake uint64, totalStake uint64, expectedSize uint64) uint64 {
+	L := uint(8 * len(vrf))
+	n := new(big.Int).SetUint64(stake)
+	rest := new(big.Int).SetUint64(totalStake - expectedSize)
+
+	target := new(big.Int).Exp(new(big.Int).SetUint64(totalStake), n, nil)
+	target.Mul(target, new(big.Int).SetBytes(vrf))
+
+	// 2^L * (T - E)^n
+	term := new(big.Int).Exp(rest, n, nil)
+	term.Lsh(term, L)
+	cdf := new(big.Int).Set(term)
+
+	mul, div := new(big.Int), new(big.Int)
+	for j := uint64(0); j < stake; j++ {
+		if target.Cmp(cdf) < 0 {
+			return j
+		}
+		// term(j+1) = term(j) * (n - j) * E / ((j + 1) * (T - E))
+		mul.SetUint64(stake - j)
+		term.Mul(term, mul)
+		mul.SetUint64(expectedSize)
+		term.Mul(term, mul)
+		div.SetUint64(j + 1)
+		div.Mul(div, rest)
+		term.Quo(term, div)
+		cdf.Add(cdf, term)
+	}
+	return stake
+}
+
+//	verify the proof of the legacy construction, then count the selected sub-users
+func VerifySortition(message []byte, pubKey []byte, vrf []byte, proof []byte, stake uint64, totalStake uint64, expectedSize uint64) (uint64, error) {
+	if err := verify(message, pubKey, vrf, proof); err != nil {
+		return 0, err
+	}
+	return Sortition(vrf, stake, totalStake, expectedSize)
+}
+
+//	verify an RFC 9381 proof, then count the selected sub-users of its random number
+func VerifyECVRFSortition(v VRF, message []byte, pubKey []byte, proof []byte, stake uint64, totalStake uint64, expectedSize uint64) (uint64, error) {
+	vrf, err := v.ProofToHash(proof)
+	if err != nil {
+		return 0, err
+	}
+	if !v.Verify(message, pubKey, vrf, proof) {
+		return 0, ErrInvalidProof
+	}
+	return Sortition(vrf, stake, totalStake, expectedSize)
+}
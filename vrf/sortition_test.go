@@ -0,0 +1,212 @@
+package vrf
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestSortition(t *testing.T) {
+	fmt.Println("Test : vrf sortition ...")
+
+	t0 := time.Now()
+
+	ones := make([]byte, Size)
+	for i := range ones {
+		ones[i] = 0xff
+	}
+
+	//	with p = 1/2 the CDF of B(2, 1/2) is 1/4, 3/4, 1
+	tests := []struct {
+		vrf                             []byte
+		stake, totalStake, expectedSize uint64
+		selected                        uint64
+	}{
+		{make([]byte, Size), 10, 20, 10, 0},
+		{ones, 10, 20, 10, 10},
+		{[]byte{0x7f}, 1, 2, 1, 0},
+		{[]byte{0x80}, 1, 2, 1, 1},
+		{[]byte{0x3f}, 2, 4, 2, 0},
+		{[]byte{0x40}, 2, 4, 2, 1},
+		{[]byte{0xbf}, 2, 4, 2, 1},
+		{[]byte{0xc0}, 2, 4, 2, 2},
+		{ones, 0, 20, 10, 0},
+		{ones, 10, 20, 0, 0},
+		{make([]byte, Size), 10, 20, 20, 10},
+	}
+	for i, test := range tests {
+		selected, err := Sortition(test.vrf, test.stake, test.totalStake, test.expectedSize)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if selected != test.selected {
+			t.Fatalf("case %d: got result %v but expected %v\n", i, selected, test.selected)
+		}
+	}
+
+	//	p = 0.05 over 100 sub-users, the mean of deterministic outputs is close to 5
+	var sum uint64
+	var counter [8]byte
+	const rounds = 2000
+	for i := 0; i < rounds; i++ {
+		binary.BigEndian.PutUint64(counter[:], uint64(i))
+		output := sha256.Sum256(counter[:])
+		selected, err := Sortition(output[:], 100, 1000, 50)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sum += selected
+	}
+	if mean := float64(sum) / rounds; mean < 4.5 || mean > 5.5 {
+		t.Fatalf("got mean %v but expected %v\n", mean, 5)
+	}
+
+	errTests := []struct {
+		stake, totalStake, expectedSize uint64
+		err                             error
+	}{
+		{10, 0, 0, ErrTotalStake},
+		{11, 10, 5, ErrStake},
+		{5, 10, 11, ErrExpectedSize},
+	}
+	for _, test := range errTests {
+		if _, err := Sortition(ones, test.stake, test.totalStake, test.expectedSize); !errors.Is(err, test.err) {
+			t.Fatalf("got result %v but expected %v\n", err, test.err)
+		}
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+//	the floating point evaluation agrees with the exact one, a random number on a CDF value
+//	is left to the exact comparison
+func TestSortitionFloat(t *testing.T) {
+	fmt.Println("Test : vrf sortition in floating point ...")
+
+	t0 := time.Now()
+
+	var counter [8]byte
+	for i := 0; i < 500; i++ {
+		binary.BigEndian.PutUint64(counter[:], uint64(i))
+		output := sha256.Sum256(counter[:])
+		stake, totalStake := uint64(output[0])+1, uint64(output[1])+300
+		expectedSize := uint64(output[2])%totalStake + 1
+		wanted := sortitionExact(output[:], stake, totalStake, expectedSize)
+		if selected, ok := sortitionFloat(output[:], stake, totalStake, expectedSize); !ok || selected != wanted {
+			t.Fatalf("case %d: got result %v, %v but expected %v\n", i, selected, ok, wanted)
+		}
+	}
+
+	//	vrf / 2^8 = 1/2 is CDF(0) of B(1, 1/2)
+	if _, ok := sortitionFloat([]byte{0x80}, 1, 2, 1); ok {
+		t.Fatalf("got result %v but expected %v\n", ok, false)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+//	the exact comparison alone takes seconds at this size
+func TestSortitionLargeStake(t *testing.T) {
+	fmt.Println("Test : vrf sortition with a stake of 10^5 ...")
+
+	output := sha256.Sum256([]byte("hello world"))
+	wanted := sortitionExact(output[:], 100000, 1000000, 10000)
+
+	t0 := time.Now()
+
+	for _, test := range []struct{ stake, totalStake, expectedSize uint64 }{
+		{100000, 1000000, 10000},
+		{100000, 200000, 100000},
+		{1000000, 2000000, 1000000},
+	} {
+		selected, err := Sortition(output[:], test.stake, test.totalStake, test.expectedSize)
+		if err != nil {
+			t.Fatal(err)
+		}
+		//	within 6 standard deviations of the mean
+		mean := float64(test.stake) * float64(test.expectedSize) / float64(test.totalStake)
+		if d := float64(selected) - mean; d*d > 36*mean {
+			t.Fatalf("got result %v but expected about %v\n", selected, mean)
+		}
+	}
+	if elapsed := time.Since(t0); elapsed > 10*time.Second {
+		t.Fatalf("got time %v but expected less than %v\n", elapsed, 10*time.Second)
+	}
+
+	selected, _ := Sortition(output[:], 100000, 1000000, 10000)
+	if selected != wanted {
+		t.Fatalf("got result %v but expected %v\n", selected, wanted)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func TestVerifySortition(t *testing.T) {
+	fmt.Println("Test : vrf verify sortition ...")
+
+	priKey, pubKey := GenerateKey()
+
+	t0 := time.Now()
+
+	message := []byte("round 1 committee")
+	vrf, proof := priKey.Prove(message)
+	wanted, err := Sortition(vrf, 100, 1000, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	selected, err := VerifySortition(message, pubKey[:], vrf, proof, 100, 1000, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if selected != wanted {
+		t.Fatalf("got result %v but expected %v\n", selected, wanted)
+	}
+
+	//	the proof does not cover another message
+	if _, err := VerifySortition([]byte("round 2 committee"), pubKey[:], vrf, proof, 100, 1000, 50); err == nil {
+		t.Fatalf("got result %v but expected %v\n", err, ErrOutputMismatch)
+	}
+
+	//	RFC 9381 suites
+	v, _ := New(Edwards25519SHA512TAI)
+	ecPriKey, ecPubKey := v.GenVRFKey()
+	ecVrf, ecProof, err := v.Prove(message, ecPriKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wanted, _ = Sortition(ecVrf, 100, 1000, 50)
+	selected, err = VerifyECVRFSortition(v, message, ecPubKey, ecProof, 100, 1000, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if selected != wanted {
+		t.Fatalf("got result %v but expected %v\n", selected, wanted)
+	}
+	ecProof[len(ecProof)-1] ^= 1
+	if _, err := VerifyECVRFSortition(v, message, ecPubKey, ecProof, 100, 1000, 50); !errors.Is(err, ErrInvalidProof) {
+		t.Fatalf("got result %v but expected %v\n", err, ErrInvalidProof)
+	}
+
+	fmt.Printf("... Passed   time: %v ms\n", time.Since(t0).Milliseconds())
+}
+
+func BenchmarkSortition(b *testing.B) {
+	output := sha256.Sum256([]byte("hello world"))
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		Sortition(output[:], 1000, 1000000, 1000)
+	}
+}
+
+func BenchmarkSortitionLargeStake(b *testing.B) {
+	output := sha256.Sum256([]byte("hello world"))
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		Sortition(output[:], 100000, 200000, 100000)
+	}
+}
@@ -4,6 +4,7 @@ package vrf
 import (
 	"bytes"
 	"crypto/rand"
+	"fmt"
 	"github.com/yahoo/coname/ed25519/edwards25519"
 	"github.com/yahoo/coname/ed25519/extra25519"
 	"golang.org/x/crypto/sha3"
@@ -26,25 +27,31 @@ func GenVRFKey() (*[PrivateKeySize]byte, []byte) {
 		log.Fatal(err)
 	}
 
-	x, _ := expandSecret(priKey)
-
-	var pubKeyP edwards25519.ExtendedGroupElement
-	edwards25519.GeScalarMultBase(&pubKeyP, x)
-
-	var pubKeyByte [PublicKeySize]byte
-	pubKeyP.ToBytes(&pubKeyByte)
-
+	pubKeyByte := publicKey(priKey)
 	copy(priKey[32:], pubKeyByte[:])
 	return priKey, pubKeyByte[:]
 }
 
-//	generate random number and its proof
+//	generate random number and its proof, BatchVerify checks the proof alone,
+//	use ProveBatchable for proofs that are batched
 func Prove(message []byte, priKey *[PrivateKeySize]byte) (vrf []byte, proof []byte) {
+	vrf, c, t, iiB, _, _ := prove(message, priKey)
+
+	// make proof
+	proof = make([]byte, ProofSize)
+	copy(proof[:32], c[:])
+	copy(proof[32:64], t[:])
+	copy(proof[64:96], iiB[:])
+	return vrf, proof
+}
+
+//	random number, challenge c, response t, ii = x*H(m) and the commitments r*G and r*H(m)
+func prove(message []byte, priKey *[PrivateKeySize]byte) (vrf []byte, c, t, iiB, grB, hrB [32]byte) {
 	// use private key expand vrf secret x
 	x, skhr := expandSecret(priKey)
 
 	var cH, rH [64]byte
-	var r, c, minusC, t, grB, hrB, iiB [32]byte
+	var r, minusC [32]byte
 	var ii, gr, hr edwards25519.ExtendedGroupElement
 
 	// hash message to curve
@@ -76,12 +83,6 @@ func Prove(message []byte, priKey *[PrivateKeySize]byte) (vrf []byte, proof []by
 	edwards25519.ScNeg(&minusC, &c)
 	edwards25519.ScMulAdd(&t, x, &minusC, &r)
 
-	// make proof
-	proof = make([]byte, ProofSize)
-	copy(proof[:32], c[:])
-	copy(proof[32:64], t[:])
-	copy(proof[64:96], iiB[:])
-
 	hash.Write(iiB[:]) // const length: Size
 	hash.Write(message)
 	vrf = make([]byte, Size)
@@ -91,8 +92,19 @@ func Prove(message []byte, priKey *[PrivateKeySize]byte) (vrf []byte, proof []by
 
 //	verify random number and its proof
 func Verify(message []byte, pubKeyBytes []byte,  vrfBytes []byte, proof []byte) bool {
-	if len(pubKeyBytes) != PublicKeySize || len(vrfBytes) != Size || len(proof) != ProofSize   {
-		return false
+	return verify(message, pubKeyBytes, vrfBytes, proof) == nil
+}
+
+//	verify random number and its proof, the error tells why the verification failed
+func verify(message []byte, pubKeyBytes []byte, vrfBytes []byte, proof []byte) error {
+	if len(pubKeyBytes) != PublicKeySize {
+		return fmt.Errorf("%w: public key is %d bytes, expected %d", ErrBadLength, len(pubKeyBytes), PublicKeySize)
+	}
+	if len(vrfBytes) != Size {
+		return fmt.Errorf("%w: random number is %d bytes, expected %d", ErrBadLength, len(vrfBytes), Size)
+	}
+	if len(proof) != ProofSize {
+		return fmt.Errorf("%w: proof is %d bytes, expected %d", ErrBadLength, len(proof), ProofSize)
 	}
 
 	var pubKey, vrf, c, t, iiB, cRef, ABytes, BBytes [32]byte
@@ -109,17 +121,17 @@ func Verify(message []byte, pubKeyBytes []byte,  vrfBytes []byte, proof []byte)
 	var hCheck [Size]byte
 	hash.Read(hCheck[:])
 	if !bytes.Equal(hCheck[:], vrf[:]) {
-		return false
+		return ErrOutputMismatch
 	}
 	hash.Reset()
 
 	var P, B, ii, iic edwards25519.ExtendedGroupElement
 	var A, hmtP, iicP edwards25519.ProjectiveGroupElement
 	if !P.FromBytesBaseGroup(&pubKey) {
-		return false
+		return fmt.Errorf("%w: public key", ErrInvalidPoint)
 	}
 	if !ii.FromBytesBaseGroup(&iiB) {
-		return false
+		return fmt.Errorf("%w: proof", ErrInvalidPoint)
 	}
 	edwards25519.GeDoubleScalarMultVartime(&A, &c, &P, &t)
 	A.ToBytes(&ABytes)
@@ -138,7 +150,10 @@ func Verify(message []byte, pubKeyBytes []byte,  vrfBytes []byte, proof []byte)
 	hash.Write(message)
 	hash.Read(cH[:])
 	edwards25519.ScReduce(&cRef, &cH)
-	return cRef == c
+	if cRef != c {
+		return ErrProofMismatch
+	}
+	return nil
 }
 
 //	compute random number
@@ -170,6 +185,18 @@ func hashToCurve(message []byte) *edwards25519.ExtendedGroupElement {
 	return &hm
 }
 
+//	public key of the seed in priKey[:32]
+func publicKey(priKey *[PrivateKeySize]byte) *[PublicKeySize]byte {
+	x, _ := expandSecret(priKey)
+
+	var pubKeyP edwards25519.ExtendedGroupElement
+	edwards25519.GeScalarMultBase(&pubKeyP, x)
+
+	pubKey := new([PublicKeySize]byte)
+	pubKeyP.ToBytes(pubKey)
+	return pubKey
+}
+
 //	expand secret
 func expandSecret(priKey *[PrivateKeySize]byte) (x, skhr *[32]byte) {
 	x, skhr = new([32]byte), new([32]byte)
@@ -0,0 +1,327 @@
+package vrf
+
+import (
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"io"
+	"log"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+)
+
+const (
+	//	compressed SEC1 point
+	pointSize  = 33
+	scalarSize = 32
+)
+
+//	ECVRF-P256-SHA256-TAI and its secp256k1 counterpart, the cofactor of both curves is 1
+type weierstrassVRF struct {
+	suite Suite
+	curve elliptic.Curve
+	//	coefficient a of y^2 = x^3 + ax + b
+	a *big.Int
+}
+
+var (
+	p256VRF      = &weierstrassVRF{suite: P256SHA256TAI, curve: elliptic.P256(), a: big.NewInt(-3)}
+	secp256k1VRF = &weierstrassVRF{suite: Secp256k1SHA256TAI, curve: btcec.S256(), a: big.NewInt(0)}
+)
+
+type point struct {
+	x, y *big.Int
+}
+
+func (v *weierstrassVRF) Suite() Suite {
+	return v.suite
+}
+
+//	Gamma || c || s
+func (v *weierstrassVRF) ProofSize() int {
+	return pointSize + challengeSize + scalarSize
+}
+
+func (v *weierstrassVRF) Size() int {
+	return sha256.Size
+}
+
+//	generate private key, a 32-byte big-endian scalar, and compressed public key
+func (v *weierstrassVRF) GenVRFKey() (priKey []byte, pubKey []byte) {
+	seed := make([]byte, scalarSize)
+	for {
+		if _, err := io.ReadFull(rand.Reader, seed); err != nil {
+			log.Fatal(err)
+		}
+		if priKey, pubKey, err := v.KeyFromSeed(seed); err == nil {
+			return priKey, pubKey
+		}
+	}
+}
+
+//	the seed is the secret scalar, it must be in [1, n)
+func (v *weierstrassVRF) KeyFromSeed(seed []byte) (priKey []byte, pubKey []byte, err error) {
+	x, err := v.secretScalar(seed)
+	if err != nil {
+		return nil, nil, err
+	}
+	return append([]byte(nil), seed...), v.encode(v.baseMult(x)), nil
+}
+
+//	generate random number and its proof, the nonce is derived as in RFC 6979
+func (v *weierstrassVRF) Prove(message []byte, priKey []byte) (vrf []byte, proof []byte, err error) {
+	x, err := v.secretScalar(priKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	pubKey := v.encode(v.baseMult(x))
+
+	H, err := v.encodeToCurve(pubKey, message)
+	if err != nil {
+		return nil, nil, err
+	}
+	hString := v.encode(H)
+	gamma := v.mult(H, x)
+
+	digest := sha256.Sum256(hString)
+	k := rfc6979Nonce(v.curve.Params().N, x, digest[:])
+	cString := v.challenge(pubKey, hString, v.encode(gamma), v.encode(v.baseMult(k)), v.encode(v.mult(H, k)))
+
+	// s = k + c * x mod n
+	s := new(big.Int).SetBytes(cString)
+	s.Mul(s, x)
+	s.Add(s, k)
+	s.Mod(s, v.curve.Params().N)
+
+	proof = make([]byte, 0, v.ProofSize())
+	proof = append(proof, v.encode(gamma)...)
+	proof = append(proof, cString...)
+	proof = append(proof, leftPad(s.Bytes(), scalarSize)...)
+	return v.gammaToHash(gamma), proof, nil
+}
+
+//	verify random number and its proof
+func (v *weierstrassVRF) Verify(message []byte, pubKey []byte, vrf []byte, proof []byte) bool {
+	if len(vrf) != v.Size() {
+		return false
+	}
+	Y, err := v.decode(pubKey)
+	if err != nil {
+		return false
+	}
+	gamma, cString, s, err := v.decodeProof(proof)
+	if err != nil {
+		return false
+	}
+
+	H, err := v.encodeToCurve(pubKey, message)
+	if err != nil {
+		return false
+	}
+
+	// U = s*B - c*Y, V = s*H - c*Gamma
+	c := new(big.Int).SetBytes(cString)
+	U := v.add(v.baseMult(s), v.neg(v.mult(Y, c)))
+	V := v.add(v.mult(H, s), v.neg(v.mult(gamma, c)))
+
+	expected := v.challenge(pubKey, v.encode(H), v.encode(gamma), v.encode(U), v.encode(V))
+	if subtle.ConstantTimeCompare(expected, cString) != 1 {
+		return false
+	}
+	return subtle.ConstantTimeCompare(v.gammaToHash(gamma), vrf) == 1
+}
+
+//	random number of a proof, the proof itself is not verified
+func (v *weierstrassVRF) ProofToHash(proof []byte) ([]byte, error) {
+	gamma, _, _, err := v.decodeProof(proof)
+	if err != nil {
+		return nil, err
+	}
+	return v.gammaToHash(gamma), nil
+}
+
+//	try-and-increment: the first Hash(suite_string || 0x01 || salt || message || ctr || 0x00)
+//	that is the x-coordinate of a point with even y
+func (v *weierstrassVRF) encodeToCurve(salt []byte, message []byte) (point, error) {
+	for ctr := 0; ctr < 256; ctr++ {
+		h := sha256.New()
+		h.Write([]byte{byte(v.suite), domainEncode})
+		h.Write(salt)
+		h.Write(message)
+		h.Write([]byte{byte(ctr), domainEnd})
+		if p, err := v.decode(append([]byte{0x02}, h.Sum(nil)...)); err == nil {
+			return p, nil
+		}
+	}
+	return point{}, ErrInvalidProof
+}
+
+//	Hash(suite_string || 0x02 || P1 || ... || P5 || 0x00) truncated to 16 bytes
+func (v *weierstrassVRF) challenge(points ...[]byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{byte(v.suite), domainChallenge})
+	for _, p := range points {
+		h.Write(p)
+	}
+	h.Write([]byte{domainEnd})
+	return h.Sum(nil)[:challengeSize]
+}
+
+//	beta = Hash(suite_string || 0x03 || Gamma || 0x00)
+func (v *weierstrassVRF) gammaToHash(gamma point) []byte {
+	h := sha256.New()
+	h.Write([]byte{byte(v.suite), domainHash})
+	h.Write(v.encode(gamma))
+	h.Write([]byte{domainEnd})
+	return h.Sum(nil)
+}
+
+func (v *weierstrassVRF) secretScalar(b []byte) (*big.Int, error) {
+	x := new(big.Int).SetBytes(b)
+	if len(b) != scalarSize || x.Sign() == 0 || x.Cmp(v.curve.Params().N) >= 0 {
+		return nil, ErrInvalidKey
+	}
+	return x, nil
+}
+
+func (v *weierstrassVRF) decodeProof(proof []byte) (gamma point, c []byte, s *big.Int, err error) {
+	if len(proof) != v.ProofSize() {
+		return point{}, nil, nil, ErrInvalidProof
+	}
+	gamma, err = v.decode(proof[:pointSize])
+	if err != nil {
+		return point{}, nil, nil, ErrInvalidProof
+	}
+	s = new(big.Int).SetBytes(proof[pointSize+challengeSize:])
+	if s.Cmp(v.curve.Params().N) >= 0 {
+		return point{}, nil, nil, ErrInvalidProof
+	}
+	return gamma, proof[pointSize : pointSize+challengeSize], s, nil
+}
+
+//	compressed SEC1 encoding, the point at infinity is the single byte 0x00
+func (v *weierstrassVRF) encode(p point) []byte {
+	if p.x.Sign() == 0 && p.y.Sign() == 0 {
+		return []byte{0x00}
+	}
+	b := make([]byte, 1, pointSize)
+	b[0] = byte(0x02 | p.y.Bit(0))
+	return append(b, leftPad(p.x.Bytes(), pointSize-1)...)
+}
+
+//	decompress a SEC1 point, p = 3 mod 4 for both curves so sqrt(a) = a^((p+1)/4)
+func (v *weierstrassVRF) decode(b []byte) (point, error) {
+	params := v.curve.Params()
+	if len(b) != pointSize || (b[0] != 0x02 && b[0] != 0x03) {
+		return point{}, ErrInvalidKey
+	}
+	x := new(big.Int).SetBytes(b[1:])
+	if x.Cmp(params.P) >= 0 {
+		return point{}, ErrInvalidKey
+	}
+
+	rhs := new(big.Int).Mul(x, x)
+	rhs.Add(rhs, v.a)
+	rhs.Mul(rhs, x)
+	rhs.Add(rhs, params.B)
+	rhs.Mod(rhs, params.P)
+
+	exp := new(big.Int).Add(params.P, big.NewInt(1))
+	exp.Rsh(exp, 2)
+	y := new(big.Int).Exp(rhs, exp, params.P)
+	if new(big.Int).Exp(y, big.NewInt(2), params.P).Cmp(rhs) != 0 {
+		return point{}, ErrInvalidKey
+	}
+	if y.Bit(0) != uint(b[0]&1) {
+		y.Sub(params.P, y)
+	}
+	if !v.curve.IsOnCurve(x, y) {
+		return point{}, ErrInvalidKey
+	}
+	return point{x, y}, nil
+}
+
+func (v *weierstrassVRF) baseMult(k *big.Int) point {
+	x, y := v.curve.ScalarBaseMult(leftPad(k.Bytes(), scalarSize))
+	return point{x, y}
+}
+
+func (v *weierstrassVRF) mult(p point, k *big.Int) point {
+	x, y := v.curve.ScalarMult(p.x, p.y, leftPad(k.Bytes(), scalarSize))
+	return point{x, y}
+}
+
+func (v *weierstrassVRF) add(p, q point) point {
+	x, y := v.curve.Add(p.x, p.y, q.x, q.y)
+	return point{x, y}
+}
+
+func (v *weierstrassVRF) neg(p point) point {
+	if p.y.Sign() == 0 {
+		return p
+	}
+	return point{p.x, new(big.Int).Sub(v.curve.Params().P, p.y)}
+}
+
+//	RFC 6979 section 3.2 with HMAC-SHA256, h1 is the message digest
+func rfc6979Nonce(q *big.Int, x *big.Int, h1 []byte) *big.Int {
+	qlen := q.BitLen()
+	rlen := (qlen + 7) / 8
+
+	bits2int := func(b []byte) *big.Int {
+		v := new(big.Int).SetBytes(b)
+		if len(b)*8 > qlen {
+			v.Rsh(v, uint(len(b)*8-qlen))
+		}
+		return v
+	}
+	z := bits2int(h1)
+	if z.Cmp(q) >= 0 {
+		z.Sub(z, q)
+	}
+	bx, bh := leftPad(x.Bytes(), rlen), leftPad(z.Bytes(), rlen)
+
+	mac := func(key []byte, data ...[]byte) []byte {
+		h := hmac.New(sha256.New, key)
+		for _, d := range data {
+			h.Write(d)
+		}
+		return h.Sum(nil)
+	}
+
+	V := make([]byte, sha256.Size)
+	K := make([]byte, sha256.Size)
+	for i := range V {
+		V[i] = 0x01
+	}
+	K = mac(K, V, []byte{0x00}, bx, bh)
+	V = mac(K, V)
+	K = mac(K, V, []byte{0x01}, bx, bh)
+	V = mac(K, V)
+
+	for {
+		var T []byte
+		for len(T) < rlen {
+			V = mac(K, V)
+			T = append(T, V...)
+		}
+		k := bits2int(T[:rlen])
+		if k.Sign() > 0 && k.Cmp(q) < 0 {
+			return k
+		}
+		K = mac(K, V, []byte{0x00})
+		V = mac(K, V)
+	}
+}
+
+func leftPad(b []byte, size int) []byte {
+	if len(b) >= size {
+		return b
+	}
+	padded := make([]byte, size)
+	copy(padded[size-len(b):], b)
+	return padded
+}